@@ -0,0 +1,540 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+	"github.com/D0Lv-1N/BUGx/internal/report"
+	"github.com/D0Lv-1N/BUGx/internal/runner"
+	"github.com/D0Lv-1N/BUGx/internal/ui"
+)
+
+// Exit codes untuk mode non-interaktif (cron / CI / script lain).
+const (
+	exitOK       = 0 // scan selesai dan minimal satu tool berjalan
+	exitNoTools  = 1 // scan selesai tapi tidak ada tool yang berhasil dijalankan
+	exitUsage    = 2 // argumen / flag tidak valid
+	exitFindings = 3 // ada temuan dengan severity >= --fail-on
+	exitFailed   = 4 // subcommand gagal (mis. laporan tidak bisa ditulis, run tidak ditemukan)
+
+	exitInterrupted = 130 // run dihentikan user (Ctrl-C dua kali), konvensi 128+SIGINT
+)
+
+// runCLI menangani subcommand non-interaktif.
+// Contoh:
+//
+//	bugx scan -m xss,sqli -t example.com --speed 80
+//	bugx scan -m all -t https://example.com
+//	bugx scan -m xss -t a.com,b.com --workers 2
+//	bugx scan -m all --targets targets.txt --workers 3
+//	bugx scan -m all -t example.com --workers 4
+//
+// Tidak pernah menunggu input dari stdin.
+func runCLI(args []string) int {
+	switch args[0] {
+	case "scan":
+		return cmdScan(args[1:])
+	case "report":
+		return cmdReport(args[1:])
+	case "history":
+		return cmdHistory(args[1:])
+	case "resume":
+		return cmdResume(args[1:])
+	case "scope":
+		return cmdScope(args[1:])
+	case "help", "-h", "-help", "--help":
+		printUsage(os.Stdout)
+		return exitOK
+	default:
+		fmt.Fprintf(os.Stderr, "[ERROR] Subcommand tidak dikenal: %s\n\n", args[0])
+		printUsage(os.Stderr)
+		return exitUsage
+	}
+}
+
+// cmdScan menjalankan mode terpilih terhadap satu atau beberapa target lalu
+// keluar.
+func cmdScan(args []string) int {
+	fs := flag.NewFlagSet("scan", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+
+	var modesRaw, targetRaw, targetsFile, toolTimeoutsRaw, reportPath, sarifPath, junitPath, failOn, scopePath string
+	var speed, workers, rate, hostRate int
+	var stepTimeout time.Duration
+	var onlyNew, keepArtifacts bool
+	var headers headerList
+	var cookie, authPath, proxy, resolver string
+	fs.StringVar(&modesRaw, "m", "", "mode scan, pisahkan dengan koma (nama atau angka, mis. xss,sqli / 1,2 / all)")
+	fs.StringVar(&modesRaw, "modes", "", "alias untuk -m")
+	fs.StringVar(&targetRaw, "t", "", "target (domain atau http(s)://url); beberapa dipisah koma, @file = daftar target")
+	fs.StringVar(&targetRaw, "target", "", "alias untuk -t")
+	fs.StringVar(&targetsFile, "targets", "", "file daftar target (domain / URL / *.wildcard, satu per baris)")
+	fs.IntVar(&workers, "workers", 1, "budget worker paralel: step/tool yang berjalan bersamaan (juga target paralel); 1 = berurutan")
+	fs.IntVar(&speed, "speed", defaultSpeed, "kecepatan (threads/concurrency tools eksternal)")
+	fs.IntVar(&speed, "s", defaultSpeed, "alias untuk --speed")
+	fs.IntVar(&rate, "rate", 0, "batas request per detik seluruh scan (0 = tanpa batas); jadi -rl nuclei/httpx, --delay dalfox")
+	fs.IntVar(&hostRate, "host-rate", 0, "batas request per detik per host (0 = tanpa batas)")
+	fs.Var(&headers, "H", "header auth \"Nama: nilai\" untuk semua tool (boleh berulang)")
+	fs.StringVar(&cookie, "cookie", "", "cookie sesi, mis. \"sid=abc; theme=dark\"")
+	fs.StringVar(&authPath, "auth", "", "file header auth (\"Nama: nilai\" per baris); default ~/BUGx/auth/<domain>.txt")
+	fs.StringVar(&proxy, "proxy", "", "proxy upstream untuk semua traffic tool, mis. http://127.0.0.1:8080 (Burp/ZAP)")
+	fs.StringVar(&resolver, "resolver", "", "DNS server untuk mode native (takeover, cors), mis. 1.1.1.1 atau 127.0.0.1:5353 (default resolv.conf)")
+	fs.DurationVar(&stepTimeout, "timeout", 0, "batas waktu default setiap step, mis. 90m (0 = tanpa batas)")
+	fs.StringVar(&toolTimeoutsRaw, "tool-timeout", "", "timeout per tool, mis. nuclei=3h,gau=20m (override --timeout)")
+	fs.StringVar(&reportPath, "report", "", "path laporan HTML (default ~/BUGx/reports/<domain>/<run-id>.html; multi-target: <nama>-<domain>.html)")
+	fs.StringVar(&sarifPath, "sarif", "", "tulis temuan sebagai SARIF 2.1.0 ke file ini")
+	fs.StringVar(&junitPath, "junit", "", "tulis hasil step & temuan sebagai JUnit XML ke file ini")
+	fs.StringVar(&failOn, "fail-on", "", "exit 3 bila ada temuan dengan severity >= nilai ini (critical|high|medium|low|info)")
+	fs.BoolVar(&onlyNew, "only-new", false, "ringkasan, laporan dan --fail-on hanya memakai temuan baru sejak run sebelumnya")
+	fs.BoolVar(&keepArtifacts, "keep-artifacts", false, "simpan file antara (gf_*, clean_*, ...) + manifest.json di folder results")
+	fs.StringVar(&scopePath, "scope", "", "file scope (teks, HackerOne .csv, .json); default ~/BUGx/scope/<domain>.*")
+	fs.Usage = func() {
+		printUsage(os.Stderr)
+		fmt.Fprintln(os.Stderr, "Flags scan:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "[ERROR] Argumen tidak dikenal: %s\n", strings.Join(fs.Args(), " "))
+		return exitUsage
+	}
+
+	selected, err := parseModeList(modesRaw)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+		return exitUsage
+	}
+	modes := normalizeAndOrderModes(selected)
+	if len(modes) == 0 {
+		fmt.Fprintln(os.Stderr, "[ERROR] Tidak ada mode valid yang dipilih (gunakan -m).")
+		return exitUsage
+	}
+
+	if targetsFile != "" {
+		targetRaw += ",@" + targetsFile
+	}
+	targets, err := parseTargets(targetRaw)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+		return exitUsage
+	}
+	if len(targets) == 0 {
+		fmt.Fprintln(os.Stderr, "[ERROR] Target tidak boleh kosong (gunakan -t atau --targets).")
+		return exitUsage
+	}
+	multi := len(targets) > 1
+	if multi && (reportPath == "-" || sarifPath == "-" || junitPath == "-") {
+		fmt.Fprintln(os.Stderr, "[ERROR] Output ke stdout (\"-\") hanya untuk satu target.")
+		return exitUsage
+	}
+
+	if speed <= 0 {
+		speed = defaultSpeed
+	}
+	threshold, err := parseThreshold(failOn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+		return exitUsage
+	}
+
+	opts := runner.DefaultOptions(speed)
+	opts.StepTimeout = stepTimeout
+	opts.Workers = workers
+	opts.KeepArtifacts = keepArtifacts
+	if rate < 0 || hostRate < 0 {
+		fmt.Fprintln(os.Stderr, "[ERROR] --rate / --host-rate tidak boleh negatif.")
+		return exitUsage
+	}
+	if rate > runner.MaxRate || hostRate > runner.MaxRate {
+		fmt.Fprintf(os.Stderr, "[ERROR] --rate / --host-rate maksimal %d.\n", runner.MaxRate)
+		return exitUsage
+	}
+	opts.RateLimit = rate
+	opts.HostRateLimit = hostRate
+	if opts.Headers, err = authHeaders(headers, cookie, authPath); err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+		return exitUsage
+	}
+	if opts.Proxy, err = runner.ParseProxy(proxy); err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+		return exitUsage
+	}
+	if opts.Resolver, err = runner.ParseResolver(resolver); err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+		return exitUsage
+	}
+	toolTimeouts, err := runner.ParseToolTimeouts(toolTimeoutsRaw)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+		return exitUsage
+	}
+	opts.ToolTimeouts = toolTimeouts
+	if scopePath != "" {
+		sc, code := loadScopeFlag(scopePath, "")
+		if code != exitOK {
+			return code
+		}
+		if sc.Empty() {
+			fmt.Fprintf(os.Stderr, "[ERROR] Scope %s tidak berisi aturan.\n", scopePath)
+			return exitUsage
+		}
+		opts.Scope = sc
+	}
+
+	ui.PrintRunLine("scan")
+	ui.PrintRunDetails(describeTargets(targets), speed, modes)
+
+	start := time.Now()
+	results := runner.RunTargets(context.Background(), modes, targets, opts)
+	for i, res := range results {
+		if onlyNew {
+			res = res.OnlyNew()
+			results[i] = res
+		}
+		if !multi {
+			ui.RenderSummary(res.Target, modes, res.Tools, res.Findings, res.Diff, runTiming(res))
+		}
+		logRunSaved(res)
+		writeHTMLReport(res, perTargetPath(reportPath, res.Domain, multi))
+		exportCI(res, perTargetPath(sarifPath, res.Domain, multi), perTargetPath(junitPath, res.Domain, multi), threshold)
+	}
+	if multi {
+		ui.RenderBatchSummary(modes, batchRows(results), batchTiming(results, time.Since(start)))
+	}
+	return runExitCode(results, len(results) == len(targets), threshold)
+}
+
+// runExitCode menentukan exit code scan / resume: dihentikan > tidak ada tool
+// yang jalan > ada temuan >= threshold. complete=false berarti sebagian target
+// tidak sempat discan (run dihentikan).
+func runExitCode(results []runner.Result, complete bool, threshold string) int {
+	aborted, toolsRan, exceeded := !complete, false, false
+	for _, res := range results {
+		aborted = aborted || res.Aborted
+		toolsRan = toolsRan || len(res.Tools) > 0
+		exceeded = thresholdExceeded(res, threshold) || exceeded
+	}
+	switch {
+	case aborted:
+		return exitInterrupted
+	case !toolsRan:
+		return exitNoTools
+	case exceeded:
+		return exitFindings
+	}
+	return exitOK
+}
+
+// headerList adalah flag yang boleh diulang (-H a -H b).
+type headerList []string
+
+func (h *headerList) String() string { return strings.Join(*h, ", ") }
+
+func (h *headerList) Set(v string) error {
+	*h = append(*h, v)
+	return nil
+}
+
+// authHeaders menggabungkan -H, --cookie dan --auth menjadi daftar header
+// tervalidasi.
+func authHeaders(raw []string, cookie, path string) ([]string, error) {
+	var out []string
+	if path != "" {
+		hs, err := runner.LoadHeaders(path)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, hs...)
+	}
+	for _, r := range raw {
+		h, err := runner.ParseHeader(r)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, h)
+	}
+	if cookie = strings.TrimSpace(cookie); cookie != "" {
+		out = append(out, runner.CookieHeader(cookie))
+	}
+	return out, nil
+}
+
+// perTargetPath menyisipkan domain sebelum ekstensi path output supaya
+// setiap target multi-target punya file sendiri (bugx.sarif ->
+// bugx-example.com.sarif).
+func perTargetPath(path, domain string, multi bool) string {
+	if !multi || path == "" || path == "-" {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + domain + ext
+}
+
+// cmdReport mengekspor temuan dari folder results hasil scan sebelumnya.
+// Contoh:
+//
+//	bugx report --format md -t example.com
+//	bugx report --format md -t example.com -m xss,sqli -o ./drafts
+//	bugx report --format sarif -t example.com -o bugx.sarif --fail-on high
+//	bugx report --format junit -t example.com -o - --fail-on medium
+//	bugx report --format html --run 20261016-202512-3fa2
+//
+// Data diambil dari history: run terakhir untuk -t, atau run tertentu (--run).
+func cmdReport(args []string) int {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+
+	var modesRaw, targetRaw, runID, format, out, failOn string
+	var onlyNew bool
+	fs.StringVar(&runID, "run", "", "run ID dari `bugx history` (default run terakhir untuk -t)")
+	fs.StringVar(&modesRaw, "m", "", "filter mode, pisahkan dengan koma (default semua mode)")
+	fs.StringVar(&modesRaw, "modes", "", "alias untuk -m")
+	fs.StringVar(&targetRaw, "t", "", "target (domain atau http(s)://url)")
+	fs.StringVar(&targetRaw, "target", "", "alias untuk -t")
+	fs.StringVar(&format, "format", "md", "format laporan: md, html, sarif, junit")
+	fs.StringVar(&out, "o", "", "folder (md) atau file output (\"-\" = stdout); default di ~/BUGx/reports/<domain>/")
+	fs.StringVar(&out, "out", "", "alias untuk -o")
+	fs.StringVar(&failOn, "fail-on", "", "exit 3 bila ada temuan dengan severity >= nilai ini (critical|high|medium|low|info)")
+	fs.BoolVar(&onlyNew, "only-new", false, "hanya temuan yang baru dibanding run sebelumnya")
+	fs.Usage = func() {
+		printUsage(os.Stderr)
+		fmt.Fprintln(os.Stderr, "Flags report:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "[ERROR] Argumen tidak dikenal: %s\n", strings.Join(fs.Args(), " "))
+		return exitUsage
+	}
+
+	domain := runner.DomainOf(targetRaw)
+	if domain == "" && runID == "" {
+		fmt.Fprintln(os.Stderr, "[ERROR] Target tidak boleh kosong (gunakan -t atau --run).")
+		return exitUsage
+	}
+	selected, err := parseModeList(modesRaw)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+		return exitUsage
+	}
+	modes := normalizeAndOrderModes(selected)
+	threshold, err := parseThreshold(failOn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+		return exitUsage
+	}
+
+	var res runner.Result
+	if runID != "" {
+		res, err = runner.LoadRun(runID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+			return exitFailed
+		}
+	} else {
+		var ok bool
+		res, ok = runner.LatestRun(domain)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "[ERROR] Belum ada run untuk %s di history (%s).\n", domain, runner.HistoryDir())
+			return exitFailed
+		}
+	}
+	res = res.ForModes(modes)
+	if onlyNew {
+		res = res.OnlyNew()
+	}
+	fmt.Fprintf(os.Stderr, "[INFO] Run %s (%s, %s)\n", res.ID, res.Domain, res.Start.Format("2006-01-02 15:04"))
+
+	// Pesan info ke stderr supaya "-o -" menghasilkan stdout yang bersih.
+	switch strings.ToLower(format) {
+	case "md", "markdown":
+		if out == "" {
+			out = report.DefaultMarkdownDir(res)
+		}
+		if len(res.Findings) == 0 {
+			fmt.Fprintf(os.Stderr, "[INFO] Tidak ada temuan di run %s.\n", res.ID)
+			break
+		}
+		paths, err := report.WriteMarkdownDrafts(out, res.Findings)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[ERROR] Gagal menulis draft Markdown: %v\n", err)
+			return exitFailed
+		}
+		fmt.Fprintf(os.Stderr, "[INFO] %d draft Markdown ditulis ke %s\n", len(paths), out)
+	case "html":
+		if out == "" {
+			out = report.DefaultPath(res, report.ExtHTML)
+		}
+		if err := report.WriteHTMLFile(out, res); err != nil {
+			fmt.Fprintf(os.Stderr, "[ERROR] Gagal menulis laporan HTML: %v\n", err)
+			return exitFailed
+		}
+		logExported("Laporan HTML", out)
+	case "sarif":
+		if out == "" {
+			out = report.DefaultPath(res, report.ExtSARIF)
+		}
+		if err := report.WriteSARIFFile(out, res); err != nil {
+			fmt.Fprintf(os.Stderr, "[ERROR] Gagal menulis SARIF: %v\n", err)
+			return exitFailed
+		}
+		logExported("SARIF", out)
+	case "junit":
+		if out == "" {
+			out = report.DefaultPath(res, report.ExtJUnit)
+		}
+		if err := report.WriteJUnitFile(out, res, threshold); err != nil {
+			fmt.Fprintf(os.Stderr, "[ERROR] Gagal menulis JUnit XML: %v\n", err)
+			return exitFailed
+		}
+		logExported("JUnit XML", out)
+	default:
+		fmt.Fprintf(os.Stderr, "[ERROR] Format tidak dikenal: %s (pilihan: md, html, sarif, junit)\n", format)
+		return exitUsage
+	}
+
+	if thresholdExceeded(res, threshold) {
+		return exitFindings
+	}
+	return exitOK
+}
+
+// logExported mencatat path hasil export (kecuali stdout).
+func logExported(what, path string) {
+	if path != "-" {
+		fmt.Fprintf(os.Stderr, "[INFO] %s: %s\n", what, path)
+	}
+}
+
+// exportCI menulis SARIF / JUnit setelah scan bila path-nya diisi. Gagal
+// menulis hanya dilaporkan, exit code tetap ditentukan hasil scan.
+func exportCI(res runner.Result, sarifPath, junitPath, threshold string) {
+	if sarifPath != "" {
+		if err := report.WriteSARIFFile(sarifPath, res); err != nil {
+			fmt.Fprintf(os.Stderr, "[WARN] Gagal menulis SARIF: %v\n", err)
+		} else if sarifPath != "-" {
+			fmt.Printf("[INFO] SARIF: %s\n", sarifPath)
+		}
+	}
+	if junitPath != "" {
+		if err := report.WriteJUnitFile(junitPath, res, threshold); err != nil {
+			fmt.Fprintf(os.Stderr, "[WARN] Gagal menulis JUnit XML: %v\n", err)
+		} else if junitPath != "-" {
+			fmt.Printf("[INFO] JUnit XML: %s\n", junitPath)
+		}
+	}
+}
+
+// parseThreshold memvalidasi nilai --fail-on ("" = tidak dipakai).
+func parseThreshold(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+	sev := findings.NormalizeSeverity(raw)
+	if sev == findings.SevUnknown {
+		return "", fmt.Errorf("severity --fail-on tidak dikenal: %s (critical|high|medium|low|info)", raw)
+	}
+	return sev, nil
+}
+
+// thresholdExceeded: true bila threshold diisi dan ada temuan >= threshold.
+func thresholdExceeded(res runner.Result, threshold string) bool {
+	if threshold == "" {
+		return false
+	}
+	n := len(findings.AtLeast(res.Findings, threshold))
+	if n > 0 {
+		fmt.Fprintf(os.Stderr, "[INFO] %d temuan dengan severity >= %s (exit %d).\n", n, threshold, exitFindings)
+	}
+	return n > 0
+}
+
+// parseModeList mengubah "xss,sqli", "1,2" atau "all" menjadi daftar nomor mode
+// (format yang sama dengan input menu, sehingga bisa diteruskan ke
+// normalizeAndOrderModes).
+func parseModeList(raw string) ([]int, error) {
+	var modes []int
+	for _, p := range strings.Split(raw, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if strings.EqualFold(p, "all") {
+			modes = append(modes, runner.ModeRunAll)
+			continue
+		}
+		if n, err := strconv.Atoi(p); err == nil {
+			if n != runner.ModeRunAll && !runner.IsMode(n) {
+				return nil, fmt.Errorf("mode tidak dikenal: %d", n)
+			}
+			modes = append(modes, n)
+			continue
+		}
+		m, ok := runner.ModeByName(p)
+		if !ok {
+			return nil, fmt.Errorf("mode tidak dikenal: %s", p)
+		}
+		modes = append(modes, m)
+	}
+	return modes, nil
+}
+
+// printUsage menampilkan bantuan singkat subcommand.
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Penggunaan:")
+	fmt.Fprintln(w, "  bugx                                   menu interaktif")
+	fmt.Fprintln(w, "  bugx scan -m <modes> -t <target[,target...]|@file> [--targets file] [--workers N]")
+	fmt.Fprintln(w, "            [--speed N] [--rate N] [--host-rate N] [--timeout D] [--tool-timeout tool=D,...]")
+	fmt.Fprintln(w, "            [--report file.html] [--sarif file] [--junit file] [--fail-on severity] [--only-new] [--keep-artifacts]")
+	fmt.Fprintln(w, "            [--scope file] [-H \"Nama: nilai\"]... [--cookie \"a=b\"] [--auth file] [--proxy url] [--resolver dns]")
+	fmt.Fprintln(w, "  bugx report --format md|html|sarif|junit -t <target> | --run <id> [-m <modes>] [-o path] [--fail-on severity] [--only-new]")
+	fmt.Fprintln(w, "  bugx history [-t <target>] [-n N] [<run-id>]")
+	fmt.Fprintln(w, "  bugx resume <run-id> [--workers N] [-H ...] [--cookie ...] [--proxy url] [--fail-on severity] [--keep-artifacts]")
+	fmt.Fprintln(w, "  bugx scope import <file> (-t <domain> | -o <file>)")
+	fmt.Fprintln(w, "  bugx scope check (-t <domain> | --scope <file>) <host/url>...")
+	fmt.Fprintln(w, "  bugx help")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Mode (nama atau nomor menu):")
+	for _, m := range runner.Modes() {
+		fmt.Fprintf(w, "  %2d  %-12s %s\n", m.ID, m.Name, m.Title)
+	}
+	fmt.Fprintf(w, "  %2d  %-12s %s\n", runner.ModeRunAll, "all", "RUN ALL")
+	fmt.Fprintf(w, "Mode custom dibaca dari %s (*.yaml, *.yml, *.json).\n", runner.CustomModesDir())
+	fmt.Fprintf(w, "Nomor mode custom %d-%d (\"id:\" di file, atau tetap per nama sejak pertama dimuat); mode bawaan baru memakai %d ke atas.\n",
+		runner.CustomModeFirst, runner.CustomModeLast, runner.CustomModeLast+1)
+	fmt.Fprintf(w, "Scope default per domain: %s/<domain>.txt (atau .csv / .json).\n", runner.ScopeDir())
+	fmt.Fprintf(w, "Header auth default per domain: %s/<domain>.txt.\n", runner.AuthDir())
+	fmt.Fprintf(w, "Fingerprint takeover tambahan: %s.\n", runner.TakeoverFingerprintsFile())
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Exit code:")
+	fmt.Fprintln(w, "  0    scan selesai")
+	fmt.Fprintln(w, "  1    tidak ada tool eksternal yang berhasil dijalankan")
+	fmt.Fprintln(w, "  2    argumen tidak valid")
+	fmt.Fprintln(w, "  3    ada temuan dengan severity >= --fail-on")
+	fmt.Fprintln(w, "  4    subcommand gagal (laporan gagal ditulis, run tidak ditemukan, ...)")
+	fmt.Fprintln(w, "  130  run dihentikan dengan Ctrl-C")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Selama scan: Ctrl-C sekali melewati step aktif, dua kali dalam 3 detik menghentikan run.")
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+	"github.com/D0Lv-1N/BUGx/internal/runner"
+)
+
+func TestRunExitCode(t *testing.T) {
+	withFindings := func(sevs ...string) runner.Result {
+		res := runner.Result{Tools: []string{"nuclei"}}
+		for _, s := range sevs {
+			res.Findings = append(res.Findings, findings.Finding{Tool: "nuclei", ID: s, Severity: s, URL: "https://a.example.com"})
+		}
+		return res
+	}
+	aborted := withFindings(findings.SevCritical)
+	aborted.Aborted = true
+
+	tests := []struct {
+		name      string
+		results   []runner.Result
+		complete  bool
+		threshold string
+		want      int
+	}{
+		{"tanpa --fail-on", []runner.Result{withFindings(findings.SevCritical)}, true, "", exitOK},
+		{"sama dengan threshold", []runner.Result{withFindings(findings.SevHigh)}, true, findings.SevHigh, exitFindings},
+		{"di atas threshold", []runner.Result{withFindings(findings.SevLow, findings.SevCritical)}, true, findings.SevHigh, exitFindings},
+		{"di bawah threshold", []runner.Result{withFindings(findings.SevMedium, findings.SevInfo)}, true, findings.SevHigh, exitOK},
+		{"severity tidak dikenal", []runner.Result{withFindings("bogus")}, true, findings.SevInfo, exitOK},
+		{"tanpa temuan", []runner.Result{withFindings()}, true, findings.SevInfo, exitOK},
+		{"satu dari beberapa target", []runner.Result{withFindings(findings.SevLow), withFindings(findings.SevHigh)}, true, findings.SevHigh, exitFindings},
+		{"run dihentikan menang", []runner.Result{aborted}, true, findings.SevHigh, exitInterrupted},
+		{"target tidak lengkap", []runner.Result{withFindings(findings.SevHigh)}, false, findings.SevHigh, exitInterrupted},
+		{"tidak ada tool", []runner.Result{{Findings: withFindings(findings.SevHigh).Findings}}, true, findings.SevHigh, exitNoTools},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runExitCode(tt.results, tt.complete, tt.threshold); got != tt.want {
+				t.Errorf("runExitCode = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseThreshold(t *testing.T) {
+	for in, want := range map[string]string{"": "", " HIGH ": findings.SevHigh, "crit": findings.SevCritical, "info": findings.SevInfo} {
+		if got, err := parseThreshold(in); err != nil || got != want {
+			t.Errorf("parseThreshold(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := parseThreshold("severe"); err == nil {
+		t.Error("parseThreshold(severe) harus error")
+	}
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+	"github.com/D0Lv-1N/BUGx/internal/runner"
+)
+
+// cmdHistory menampilkan run yang tersimpan di ~/BUGx/history.
+// Contoh:
+//
+//	bugx history                      daftar semua run (terbaru dulu)
+//	bugx history -t example.com -n 5  5 run terakhir untuk satu domain
+//	bugx history 20261016-202512-3fa2 detail satu run
+//	bugx history --json <run-id>      record mentah (JSON)
+func cmdHistory(args []string) int {
+	fs := flag.NewFlagSet("history", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+
+	var targetRaw string
+	var limit int
+	var asJSON bool
+	fs.StringVar(&targetRaw, "t", "", "filter domain / target")
+	fs.StringVar(&targetRaw, "target", "", "alias untuk -t")
+	fs.IntVar(&limit, "n", 20, "jumlah run yang ditampilkan (0 = semua)")
+	fs.BoolVar(&asJSON, "json", false, "tampilkan sebagai JSON")
+	fs.Usage = func() {
+		printUsage(os.Stderr)
+		fmt.Fprintln(os.Stderr, "Flags history:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	switch fs.NArg() {
+	case 0:
+	case 1:
+		return showRun(fs.Arg(0), asJSON)
+	default:
+		fmt.Fprintf(os.Stderr, "[ERROR] Argumen tidak dikenal: %s\n", strings.Join(fs.Args()[1:], " "))
+		return exitUsage
+	}
+
+	runs, errs := runner.ListRuns(runner.DomainOf(targetRaw))
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "[WARN] Record history dilewati: %v\n", err)
+	}
+	if limit > 0 && len(runs) > limit {
+		runs = runs[:limit]
+	}
+
+	if asJSON {
+		return printJSON(runs)
+	}
+	if len(runs) == 0 {
+		fmt.Printf("[INFO] Belum ada run di history (%s).\n", runner.HistoryDir())
+		return exitOK
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RUN ID\tMULAI\tDOMAIN\tMODE\tDURASI\tSTATUS\tTEMUAN (C/H/M/L/I)")
+	for _, r := range runs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.ID,
+			r.Start.Format("2006-01-02 15:04"),
+			r.Domain,
+			strings.Join(r.Modes, ","),
+			runDuration(r.Start, r.End),
+			r.Status(),
+			countsString(r.Counts),
+		)
+	}
+	tw.Flush()
+	return exitOK
+}
+
+// showRun menampilkan detail satu run: info, step, temuan, folder results.
+func showRun(id string, asJSON bool) int {
+	r, err := runner.LoadRun(id)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+		if errors.Is(err, runner.ErrRunNotFound) {
+			fmt.Fprintln(os.Stderr, "Gunakan `bugx history` untuk melihat daftar run.")
+		}
+		return exitFailed
+	}
+	if asJSON {
+		return printJSON(r)
+	}
+
+	fmt.Printf("Run ID      : %s\n", r.ID)
+	fmt.Printf("Target      : %s\n", r.Target)
+	fmt.Printf("Mode(s)     : %s\n", strings.Join(r.Modes, ", "))
+	fmt.Printf("Speed       : %d\n", r.Speed)
+	if r.RateLimit > 0 || r.HostRateLimit > 0 {
+		fmt.Printf("Rate limit  : global %d, per host %d req/s (0 = tanpa batas)\n", r.RateLimit, r.HostRateLimit)
+	}
+	fmt.Printf("Mulai       : %s\n", r.Start.Format("2006-01-02 15:04:05"))
+	fmt.Printf("Durasi      : %s\n", runDuration(r.Start, r.End))
+	if r.Workers > 1 {
+		fmt.Printf("Workers     : %d (%s)\n", r.Workers, runTiming(r))
+	}
+	fmt.Printf("Status      : %s\n", r.Status())
+	if len(r.Auth) > 0 {
+		fmt.Printf("Header auth : %s\n", strings.Join(r.Auth, ", "))
+	}
+	if r.Proxy != "" {
+		fmt.Printf("Proxy       : %s\n", r.Proxy)
+	}
+	if len(r.Tools) > 0 {
+		fmt.Printf("Tools Used  : %s\n", strings.Join(r.Tools, ", "))
+	}
+
+	fmt.Println()
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MODE\tSTEP\tSTATUS\tEXIT\tDURASI\tKETERANGAN")
+	for _, st := range r.Steps {
+		exit := "-"
+		if st.ExitCode >= 0 {
+			exit = fmt.Sprint(st.ExitCode)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", st.Mode, st.Name, st.Status, exit, st.Duration.Round(time.Second), st.Error)
+	}
+	tw.Flush()
+	if dirs := stepLogDirs(r.Steps); len(dirs) > 0 {
+		fmt.Println()
+		fmt.Println("Log step (command, stdout/stderr, exit code):")
+		for _, d := range dirs {
+			fmt.Printf("  %s\n", d)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("Findings    : %d (%s)\n", len(r.Findings), severityCounts(r.Findings))
+	showNew := r.Diff != nil && r.Diff.PrevID != ""
+	for _, f := range r.Findings {
+		name := f.Name
+		if name == "" {
+			name = f.ID
+		}
+		mark := ""
+		if showNew && f.New {
+			mark = " (baru)"
+		}
+		fmt.Printf("  [%-8s] %-9s %s -> %s%s\n", strings.ToUpper(f.Severity), f.Mode, name, f.URL, mark)
+	}
+	if d := r.Diff; d != nil && d.PrevID != "" {
+		fmt.Printf("Baru sejak  : run %s (subs +%d, hosts +%d, urls +%d, temuan +%d)\n",
+			d.PrevID, d.Subs.New, d.Hosts.New, d.URLs.New, d.NewFindings)
+	}
+
+	if len(r.Artifacts) > 0 {
+		fmt.Println()
+		fmt.Println("Artefak:")
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "  MODE\tSTEP\tBARIS\tFILE")
+		for _, a := range r.Artifacts {
+			fmt.Fprintf(tw, "  %s\t%s\t%d\t%s\n", a.Mode, a.Step, a.Lines, a.File)
+		}
+		tw.Flush()
+	}
+
+	if len(r.ResultsDirs) > 0 {
+		fmt.Println()
+		fmt.Println("Folder results:")
+		for _, m := range append([]string{"recon"}, r.Modes...) {
+			if dir, ok := r.ResultsDirs[m]; ok {
+				fmt.Printf("  %-10s %s\n", m, dir)
+			}
+		}
+	}
+	return exitOK
+}
+
+func printJSON(v any) int {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+		return exitFailed
+	}
+	return exitOK
+}
+
+// stepLogDirs returns the distinct folders holding the run's step logs.
+func stepLogDirs(steps []runner.StepRecord) []string {
+	var dirs []string
+	seen := make(map[string]bool)
+	for _, st := range steps {
+		if st.Log == "" {
+			continue
+		}
+		if d := filepath.Dir(st.Log); !seen[d] {
+			seen[d] = true
+			dirs = append(dirs, d)
+		}
+	}
+	return dirs
+}
+
+func runDuration(start, end time.Time) string {
+	if end.IsZero() {
+		return "-"
+	}
+	return end.Sub(start).Round(time.Second).String()
+}
+
+// severityCounts -> "0/2/1/0/3" (critical/high/medium/low/info).
+func severityCounts(list []findings.Finding) string {
+	return countsString(findings.CountBySeverity(list))
+}
+
+// countsString formats per-severity counts as critical/high/medium/low/info.
+func countsString(c map[string]int) string {
+	return fmt.Sprintf("%d/%d/%d/%d/%d",
+		c[findings.SevCritical], c[findings.SevHigh], c[findings.SevMedium], c[findings.SevLow], c[findings.SevInfo])
+}
@@ -1,30 +1,48 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/D0Lv-1N/BUGx/internal/report"
 	"github.com/D0Lv-1N/BUGx/internal/runner"
 	"github.com/D0Lv-1N/BUGx/internal/ui"
 )
 
+// defaultSpeed dipakai bila user tidak mengisi kecepatan.
+const defaultSpeed = 50
+
 // main adalah entrypoint utama BUG-X.
 // Tugas:
 // - Menampilkan menu interaktif.
+// - Memuat mode custom dari ~/BUGx/modes/ (tampil setelah mode bawaan).
 // - Mengizinkan multi-select mode (1,2,3,...).
 // - Menjamin urutan eksekusi:
 //   - Input "1,3,2" -> tetap dieksekusi sebagai 1 -> 2 -> 3.
-//   - Mode RUN ALL (9) -> eksekusi 1..8 berurutan.
+//   - Mode RUN ALL (9) -> eksekusi semua mode terdaftar berurutan.
+//
+// - Mengoper target (satu, beberapa, atau @file) & speed ke lapisan runner.
+// - Menampilkan ringkasan + tools yang dipakai (gabungan bila multi-target).
+// - Mencatat run di ~/BUGx/history + laporan HTML di ~/BUGx/reports/<domain>/.
 //
-// - Mengoper target & speed ke lapisan runner.
-// - Menampilkan ringkasan + tools yang dipakai.
+// Jika dipanggil dengan argumen (mis. "bugx scan ..."), BUG-X berjalan
+// non-interaktif lewat runCLI dan keluar dengan exit code yang sesuai.
 func main() {
-	const defaultSpeed = 50
+	for _, err := range runner.LoadCustomModes() {
+		fmt.Fprintf(os.Stderr, "[WARN] Mode custom %v\n", err)
+	}
+
+	if len(os.Args) > 1 {
+		os.Exit(runCLI(os.Args[1:]))
+	}
 
 	for {
 		ui.ClearScreen()
-		ui.PrintMainMenu()
+		ui.PrintMainMenu(menuItems())
 
 		selection := ui.ReadModes()
 		if selection.Exit {
@@ -34,17 +52,21 @@ func main() {
 		modes := normalizeAndOrderModes(selection.Modes)
 		if len(modes) == 0 {
 			fmt.Println("[INFO] Tidak ada mode valid yang dipilih. Tekan ENTER untuk kembali ke menu...")
-			ui.PrintSummary("", nil, nil)
+			ui.PrintSummary("", nil, nil, nil, nil, ui.Timing{})
 			continue
 		}
 
 		// Setup target & speed
 		ui.PrintSetupTarget()
-		targetRaw := strings.TrimSpace(ui.ReadTarget())
-		target := normalizeTarget(targetRaw)
-		if target == "" {
+		targets, err := parseTargets(ui.ReadTarget())
+		if err != nil {
+			fmt.Printf("[WARN] %v. Tekan ENTER untuk kembali ke menu...\n", err)
+			ui.PrintSummary("", nil, nil, nil, nil, ui.Timing{})
+			continue
+		}
+		if len(targets) == 0 {
 			fmt.Println("[WARN] Target tidak boleh kosong. Tekan ENTER untuk kembali ke menu...")
-			ui.PrintSummary("", nil, nil)
+			ui.PrintSummary("", nil, nil, nil, nil, ui.Timing{})
 			continue
 		}
 
@@ -52,22 +74,164 @@ func main() {
 		if speed <= 0 {
 			speed = defaultSpeed
 		}
+		opts := runner.DefaultOptions(speed)
+		opts.RateLimit = ui.ReadRateLimit()
+		if opts.Headers, err = menuHeaders(ui.ReadAuth()); err != nil {
+			fmt.Printf("[WARN] %v. Tekan ENTER untuk kembali ke menu...\n", err)
+			ui.PrintSummary("", nil, nil, nil, nil, ui.Timing{})
+			continue
+		}
+		if opts.Proxy, err = runner.ParseProxy(ui.ReadProxy()); err != nil {
+			fmt.Printf("[WARN] %v. Tekan ENTER untuk kembali ke menu...\n", err)
+			ui.PrintSummary("", nil, nil, nil, nil, ui.Timing{})
+			continue
+		}
+		opts.Workers = ui.ReadWorkers(1)
 
-		ui.PrintRunHeader(target, speed, modes)
+		ui.PrintRunHeader(describeTargets(targets), speed, modes)
 
-		// Eksekusi semua mode secara berurutan (sama behavior dengan RUN ALL)
-		toolsUsed := runner.RunModes(modes, target, speed)
+		// Eksekusi semua mode (urutan sama dengan RUN ALL; paralel bila
+		// worker > 1). Ctrl-C selama run hanya membatalkan step / run, bukan
+		// program.
+		start := time.Now()
+		results := runner.RunTargets(context.Background(), modes, targets, opts)
+		for _, res := range results {
+			if res.Aborted {
+				fmt.Printf("[INFO] Run untuk %s dihentikan sebelum semua step selesai.\n", res.Domain)
+			}
+			logRunSaved(res)
+			writeHTMLReport(res, "")
+		}
 
 		// Ringkasan + tunggu ENTER
-		ui.PrintSummary(target, modes, toolsUsed)
+		if len(results) == 1 {
+			res := results[0]
+			ui.PrintSummary(res.Target, modes, res.Tools, res.Findings, res.Diff, runTiming(res))
+		} else {
+			ui.PrintBatchSummary(modes, batchRows(results), batchTiming(results, time.Since(start)))
+		}
+	}
+}
+
+// parseTargets memecah input target (koma, @file, file .txt) dan
+// menormalkan setiap entri (lihat normalizeTarget).
+func parseTargets(raw string) ([]string, error) {
+	list, err := runner.ExpandTargets(raw)
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, t := range list {
+		if t = normalizeTarget(t); t != "" {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// menuHeaders: input header auth menu -> satu header, atau isi @file.
+func menuHeaders(raw string) ([]string, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	if path, ok := strings.CutPrefix(raw, "@"); ok {
+		return runner.LoadHeaders(path)
+	}
+	h, err := runner.ParseHeader(raw)
+	if err != nil {
+		return nil, err
+	}
+	return []string{h}, nil
+}
+
+// describeTargets -> "https://a.com" atau "3 target (a.com, b.com, c.com)".
+func describeTargets(targets []string) string {
+	if len(targets) == 1 {
+		return targets[0]
+	}
+	var names []string
+	for i, t := range targets {
+		if i == 3 {
+			names = append(names, "...")
+			break
+		}
+		names = append(names, runner.DomainOf(t))
+	}
+	return fmt.Sprintf("%d target (%s)", len(targets), strings.Join(names, ", "))
+}
+
+// batchRows menyiapkan baris ringkasan gabungan multi-target.
+func batchRows(results []runner.Result) []ui.TargetSummary {
+	rows := make([]ui.TargetSummary, 0, len(results))
+	for _, res := range results {
+		rows = append(rows, ui.TargetSummary{
+			Target:   res.Domain,
+			RunID:    res.ID,
+			Status:   res.Status(),
+			Tools:    res.Tools,
+			Findings: res.Findings,
+			Diff:     res.Diff,
+			Timing:   runTiming(res),
+		})
+	}
+	return rows
+}
+
+// runTiming: wall clock run vs total durasi step (penghematan paralel).
+func runTiming(res runner.Result) ui.Timing {
+	wall, steps := res.Timing()
+	return ui.Timing{Wall: wall, Steps: steps}
+}
+
+// batchTiming: wall clock seluruh batch vs total durasi step semua target.
+func batchTiming(results []runner.Result, wall time.Duration) ui.Timing {
+	t := ui.Timing{Wall: wall}
+	for _, res := range results {
+		_, steps := res.Timing()
+		t.Steps += steps
+	}
+	return t
+}
+
+// logRunSaved menampilkan run ID supaya bisa dibuka lagi lewat `bugx history`.
+func logRunSaved(res runner.Result) {
+	if res.ID != "" {
+		fmt.Printf("[INFO] Run %s tersimpan (bugx history %s)\n", res.ID, res.ID)
+	}
+	if res.Aborted {
+		fmt.Printf("[INFO] Lanjutkan dari step terakhir: bugx resume %s\n", res.ID)
+	}
+}
+
+// writeHTMLReport menulis laporan HTML satu run. path kosong -> lokasi default
+// (~/BUGx/reports/<domain>/<waktu>.html). Gagal menulis laporan tidak
+// menggagalkan run, hanya dilaporkan.
+func writeHTMLReport(res runner.Result, path string) string {
+	if path == "" {
+		path = report.DefaultPath(res, report.ExtHTML)
+	}
+	if err := report.WriteHTMLFile(path, res); err != nil {
+		fmt.Fprintf(os.Stderr, "[WARN] Gagal menulis laporan HTML: %v\n", err)
+		return ""
+	}
+	fmt.Printf("[INFO] Laporan HTML: %s\n", path)
+	return path
+}
+
+// menuItems membangun daftar menu dari registry mode runner.
+func menuItems() []ui.MenuItem {
+	var items []ui.MenuItem
+	for _, m := range runner.Modes() {
+		items = append(items, ui.MenuItem{Number: m.ID, Title: m.Title})
 	}
+	return items
 }
 
 // normalizeAndOrderModes:
 // - Hapus duplikat.
-// - Jika ada 9 (RUN ALL) -> jadikan [1..8] (urut).
-// - Kalau multi input tanpa 9: urutkan ascending (1..8).
-// - Hanya izinkan 1..9, lainnya dibuang.
+// - Jika ada 9 (RUN ALL) -> jadikan semua mode terdaftar (urut).
+// - Kalau multi input tanpa 9: urutkan ascending.
+// - Hanya izinkan mode terdaftar + 9, lainnya dibuang.
 func normalizeAndOrderModes(input []int) []int {
 	if len(input) == 0 {
 		return nil
@@ -77,20 +241,20 @@ func normalizeAndOrderModes(input []int) []int {
 	hasRunAll := false
 
 	for _, m := range input {
-		if m == 9 {
+		if m == runner.ModeRunAll {
 			hasRunAll = true
 			continue
 		}
-		if m >= 1 && m <= 8 {
+		if runner.IsMode(m) {
 			seen[m] = struct{}{}
 		}
 	}
 
 	var result []int
 	if hasRunAll {
-		// RUN ALL = 1..8 berurutan
-		for m := 1; m <= 8; m++ {
-			result = append(result, m)
+		// RUN ALL = semua mode terdaftar berurutan
+		for _, m := range runner.Modes() {
+			result = append(result, m.ID)
 		}
 		return result
 	}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/D0Lv-1N/BUGx/internal/runner"
+	"github.com/D0Lv-1N/BUGx/internal/ui"
+)
+
+// cmdResume melanjutkan run yang terhenti dari step terakhir yang selesai.
+// Contoh:
+//
+//	bugx resume 20261016-202512-3fa2
+//	bugx resume 20261016-202512-3fa2 --cookie "sid=abc" --fail-on high
+func cmdResume(args []string) int {
+	fs := flag.NewFlagSet("resume", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+
+	var toolTimeoutsRaw, reportPath, failOn, cookie, authPath, proxy string
+	var stepTimeout time.Duration
+	var workers int
+	var headers headerList
+	var keepArtifacts bool
+	fs.Var(&headers, "H", "header auth \"Nama: nilai\" (nilai header run asal tidak disimpan)")
+	fs.StringVar(&cookie, "cookie", "", "cookie sesi, mis. \"sid=abc; theme=dark\"")
+	fs.StringVar(&authPath, "auth", "", "file header auth (\"Nama: nilai\" per baris)")
+	fs.StringVar(&proxy, "proxy", "", "proxy upstream (default proxy run asal bila tanpa kredensial)")
+	fs.IntVar(&workers, "workers", 0, "budget worker paralel (0 = sama dengan run asal)")
+	fs.DurationVar(&stepTimeout, "timeout", 0, "batas waktu default setiap step, mis. 90m (0 = tanpa batas)")
+	fs.StringVar(&toolTimeoutsRaw, "tool-timeout", "", "timeout per tool, mis. nuclei=3h,gau=20m (override --timeout)")
+	fs.StringVar(&reportPath, "report", "", "path laporan HTML (default ~/BUGx/reports/<domain>/<run-id>.html)")
+	fs.StringVar(&failOn, "fail-on", "", "exit 3 bila ada temuan dengan severity >= nilai ini (critical|high|medium|low|info)")
+	fs.BoolVar(&keepArtifacts, "keep-artifacts", false, "simpan file antara + manifest.json di folder results")
+	fs.Usage = func() {
+		printUsage(os.Stderr)
+		fmt.Fprintln(os.Stderr, "Flags resume:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(reorderFlags(fs, args)); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "[ERROR] Gunakan: bugx resume <run-id> (lihat `bugx history`).")
+		return exitUsage
+	}
+	threshold, err := parseThreshold(failOn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+		return exitUsage
+	}
+
+	// Speed 0: dipakai speed run asal.
+	opts := runner.DefaultOptions(0)
+	opts.StepTimeout = stepTimeout
+	opts.Workers = workers
+	opts.KeepArtifacts = keepArtifacts
+	toolTimeouts, err := runner.ParseToolTimeouts(toolTimeoutsRaw)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+		return exitUsage
+	}
+	opts.ToolTimeouts = toolTimeouts
+	if opts.Headers, err = authHeaders(headers, cookie, authPath); err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+		return exitUsage
+	}
+	if opts.Proxy, err = runner.ParseProxy(proxy); err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+		return exitUsage
+	}
+
+	ui.PrintRunLine("resume " + fs.Arg(0))
+	res, err := runner.ResumeRun(context.Background(), fs.Arg(0), opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+		if errors.Is(err, runner.ErrRunNotFound) {
+			fmt.Fprintln(os.Stderr, "Gunakan `bugx history` untuk melihat daftar run.")
+		}
+		return exitFailed
+	}
+
+	ui.RenderSummary(res.Target, modeIDs(res.Modes), res.Tools, res.Findings, res.Diff, runTiming(res))
+	logRunSaved(res)
+	writeHTMLReport(res, reportPath)
+	return runExitCode([]runner.Result{res}, true, threshold)
+}
+
+// modeIDs mengubah nama mode di record run menjadi nomor menu (untuk ringkasan).
+func modeIDs(names []string) []int {
+	var ids []int
+	for _, n := range names {
+		if id, ok := runner.ModeByName(strings.TrimSpace(n)); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/D0Lv-1N/BUGx/internal/runner"
+	"github.com/D0Lv-1N/BUGx/internal/scope"
+)
+
+// cmdScope mengelola file scope.
+// Contoh:
+//
+//	bugx scope import h1_scopes.csv -t example.com   -> ~/BUGx/scope/example.com.txt
+//	bugx scope import targets.json -o scope.txt
+//	bugx scope check -t example.com https://admin.example.com/x api.example.com
+//	bugx scope check --scope scope.txt dev.example.com
+func cmdScope(args []string) int {
+	if len(args) == 0 {
+		printUsage(os.Stderr)
+		return exitUsage
+	}
+	switch args[0] {
+	case "import":
+		return cmdScopeImport(args[1:])
+	case "check":
+		return cmdScopeCheck(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "[ERROR] Subcommand scope tidak dikenal: %s (import|check)\n", args[0])
+		return exitUsage
+	}
+}
+
+// cmdScopeImport mengubah export HackerOne (.csv), JSON bounty-targets atau
+// salinan teks halaman program menjadi file scope teks BUGx.
+func cmdScopeImport(args []string) int {
+	fs := flag.NewFlagSet("scope import", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	var targetRaw, out string
+	fs.StringVar(&targetRaw, "t", "", "domain target; hasil ditulis ke ~/BUGx/scope/<domain>.txt (dipakai otomatis saat scan)")
+	fs.StringVar(&out, "o", "", "file output (\"-\" = stdout)")
+	if err := fs.Parse(reorderFlags(fs, args)); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "[ERROR] Gunakan: bugx scope import <file> (-t <domain> | -o <file>)")
+		return exitUsage
+	}
+	if out == "" {
+		domain := runner.DomainOf(targetRaw)
+		if domain == "" {
+			fmt.Fprintln(os.Stderr, "[ERROR] Isi -t <domain> atau -o <file>.")
+			return exitUsage
+		}
+		out = filepath.Join(runner.ScopeDir(), domain+".txt")
+	}
+
+	sc, err := scope.Load(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+		return exitFailed
+	}
+	warnScope(sc)
+	if sc.Empty() {
+		fmt.Fprintln(os.Stderr, "[ERROR] Tidak ada aset web (URL / wildcard / CIDR) di file tersebut.")
+		return exitFailed
+	}
+
+	w := os.Stdout
+	if out != "-" {
+		if err := os.MkdirAll(filepath.Dir(out), 0o755); err != nil {
+			fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+			return exitFailed
+		}
+		f, err := os.Create(out)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+			return exitFailed
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := sc.Write(w); err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+		return exitFailed
+	}
+	logExported(fmt.Sprintf("Scope (%d in-scope, %d out-of-scope)", len(sc.Includes), len(sc.Excludes)), out)
+	return exitOK
+}
+
+// cmdScopeCheck menampilkan keputusan scope untuk setiap entri.
+func cmdScopeCheck(args []string) int {
+	fs := flag.NewFlagSet("scope check", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	var targetRaw, scopePath string
+	fs.StringVar(&targetRaw, "t", "", "pakai scope default ~/BUGx/scope/<domain>.*")
+	fs.StringVar(&scopePath, "scope", "", "file scope")
+	if err := fs.Parse(reorderFlags(fs, args)); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	sc, code := loadScopeFlag(scopePath, targetRaw)
+	if code != exitOK {
+		return code
+	}
+	warnScope(sc)
+	if sc.Empty() {
+		fmt.Fprintln(os.Stderr, "[ERROR] Scope kosong / tidak ditemukan (gunakan --scope atau -t).")
+		return exitUsage
+	}
+	for _, e := range fs.Args() {
+		if ok, reason := sc.Check(e); ok {
+			fmt.Printf("IN   %s\n", e)
+		} else {
+			fmt.Printf("OUT  %s  (%s)\n", e, reason)
+		}
+	}
+	return exitOK
+}
+
+// loadScopeFlag memuat --scope, atau scope default domain bila path kosong.
+func loadScopeFlag(path, targetRaw string) (*scope.Scope, int) {
+	var sc *scope.Scope
+	var err error
+	if path != "" {
+		sc, err = scope.Load(path)
+	} else if domain := runner.DomainOf(targetRaw); domain != "" {
+		sc, err = runner.DefaultScope(domain)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] Scope: %v\n", err)
+		return nil, exitUsage
+	}
+	return sc, exitOK
+}
+
+// warnScope menampilkan aturan yang dilewati saat scope dimuat.
+func warnScope(sc *scope.Scope) {
+	if sc == nil {
+		return
+	}
+	for _, w := range sc.Warnings {
+		fmt.Fprintf(os.Stderr, "[WARN] Scope %s: %s\n", filepath.Base(sc.Source), w)
+	}
+}
+
+// reorderFlags memindahkan argumen posisi ke belakang supaya flag boleh
+// ditulis setelahnya ("check a.com -t x" == "check -t x a.com"). Flag bool
+// (mis. --keep-artifacts) tidak memakan argumen berikutnya sebagai nilai.
+func reorderFlags(fs *flag.FlagSet, args []string) []string {
+	var flags, pos []string
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a == "--" {
+			pos = append(pos, args[i+1:]...)
+			break
+		}
+		if strings.HasPrefix(a, "-") && a != "-" {
+			flags = append(flags, a)
+			if !strings.Contains(a, "=") && !isBoolFlag(fs, a) && i+1 < len(args) {
+				flags = append(flags, args[i+1])
+				i++
+			}
+			continue
+		}
+		pos = append(pos, a)
+	}
+	if len(pos) == 0 {
+		return flags
+	}
+	// "--" menjaga argumen posisi berawalan "-" tetap posisi.
+	return append(append(flags, "--"), pos...)
+}
+
+// isBoolFlag melaporkan apakah argumen "-nama"/"--nama" adalah flag bool.
+func isBoolFlag(fs *flag.FlagSet, arg string) bool {
+	f := fs.Lookup(strings.TrimLeft(arg, "-"))
+	if f == nil {
+		return false
+	}
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
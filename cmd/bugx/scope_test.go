@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestReorderFlags(t *testing.T) {
+	newFS := func() *flag.FlagSet {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fs.String("fail-on", "", "")
+		fs.String("t", "", "")
+		fs.Bool("keep-artifacts", false, "")
+		return fs
+	}
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"posisi sebelum flag string", []string{"a.com", "-t", "x"}, []string{"-t", "x", "--", "a.com"}},
+		{"posisi setelah flag string", []string{"-t", "x", "a.com"}, []string{"-t", "x", "--", "a.com"}},
+		{"flag bool sebelum posisi", []string{"--keep-artifacts", "run-1", "--fail-on", "high"}, []string{"--keep-artifacts", "--fail-on", "high", "--", "run-1"}},
+		{"flag bool di akhir", []string{"run-1", "--keep-artifacts"}, []string{"--keep-artifacts", "--", "run-1"}},
+		{"flag bool dengan =", []string{"--keep-artifacts=false", "run-1"}, []string{"--keep-artifacts=false", "--", "run-1"}},
+		{"flag string dengan =", []string{"run-1", "--fail-on=high", "b"}, []string{"--fail-on=high", "--", "run-1", "b"}},
+		{"-- mengakhiri flag", []string{"-t", "x", "--", "-a.com", "--keep-artifacts"}, []string{"-t", "x", "--", "-a.com", "--keep-artifacts"}},
+		{"- adalah posisi", []string{"-", "-t", "x"}, []string{"-t", "x", "--", "-"}},
+		{"hanya flag", []string{"--keep-artifacts", "-t", "x"}, []string{"--keep-artifacts", "-t", "x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := newFS()
+			got := reorderFlags(fs, tt.args)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("reorderFlags(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+			if err := fs.Parse(got); err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+		})
+	}
+}
+
+func TestReorderFlagsResumeArgs(t *testing.T) {
+	fs := flag.NewFlagSet("resume", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	keep := fs.Bool("keep-artifacts", false, "")
+	failOn := fs.String("fail-on", "", "")
+	if err := fs.Parse(reorderFlags(fs, []string{"--keep-artifacts", "run-1", "--fail-on", "high"})); err != nil {
+		t.Fatal(err)
+	}
+	if !*keep || *failOn != "high" || fs.NArg() != 1 || fs.Arg(0) != "run-1" {
+		t.Fatalf("keep=%v fail-on=%q args=%q", *keep, *failOn, fs.Args())
+	}
+}
@@ -1,3 +1,5 @@
 module github.com/D0Lv-1N/BUGx
 
 go 1.21
+
+require gopkg.in/yaml.v3 v3.0.1
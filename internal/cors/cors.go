@@ -0,0 +1,144 @@
+// Package cors mendeteksi konfigurasi CORS yang tidak aman: server yang
+// memantulkan Origin penyerang (atau null / bypass prefix-suffix) di
+// Access-Control-Allow-Origin, terutama bersama Allow-Credentials: true.
+package cors
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+	"github.com/D0Lv-1N/BUGx/internal/probe"
+)
+
+// AttackerDomain adalah domain penyerang pada Origin uji.
+const AttackerDomain = "evil-bugx.com"
+
+// Jenis probe Origin.
+const (
+	KindArbitrary  = "arbitrary-origin"  // https://evil-bugx.com
+	KindNull       = "null-origin"       // null (iframe sandbox, file://)
+	KindPrefix     = "prefix-bypass"     // https://example.com.evil-bugx.com
+	KindSuffix     = "suffix-bypass"     // https://evil-bugxexample.com
+	KindUnderscore = "underscore-bypass" // https://example.com_.evil-bugx.com (Safari)
+	KindSubdomain  = "subdomain-trust"   // https://bugx-test.example.com
+	KindScheme     = "insecure-scheme"   // http://host (MITM origin dipercaya)
+)
+
+// Probe adalah satu Origin yang dikirim.
+type Probe struct {
+	Kind   string
+	Origin string
+}
+
+// Probes returns the origins to test for targetURL. root adalah domain
+// program (example.com) untuk bypass prefix/suffix/subdomain.
+func Probes(targetURL, root string) []Probe {
+	u, err := url.Parse(targetURL)
+	if err != nil || u.Host == "" {
+		return nil
+	}
+	host := u.Hostname()
+	if root == "" {
+		root = host
+	}
+	probes := []Probe{
+		{KindArbitrary, "https://" + AttackerDomain},
+		{KindNull, "null"},
+		{KindPrefix, "https://" + root + "." + AttackerDomain},
+		{KindSuffix, "https://evil-bugx" + root},
+		{KindUnderscore, "https://" + root + "_." + AttackerDomain},
+		{KindSubdomain, "https://bugx-test." + root},
+	}
+	if u.Scheme == "https" {
+		probes = append(probes, Probe{KindScheme, "http://" + host})
+	}
+	return probes
+}
+
+// Issue adalah satu kombinasi ACAO/ACAC yang tidak aman.
+type Issue struct {
+	Probe       Probe
+	AllowOrigin string
+	Credentials bool
+	Severity    string
+	Title       string
+}
+
+// titles per jenis probe.
+var titles = map[string]string{
+	KindArbitrary:  "CORS memantulkan Origin sembarang",
+	KindNull:       "CORS mempercayai Origin null",
+	KindPrefix:     "CORS bypass prefix (domain.attacker)",
+	KindSuffix:     "CORS bypass suffix (attackerdomain)",
+	KindUnderscore: "CORS bypass karakter khusus (_)",
+	KindSubdomain:  "CORS mempercayai semua subdomain",
+	KindScheme:     "CORS mempercayai Origin http://",
+}
+
+// Evaluate menilai respons untuk probe. Origin yang dipantulkan (atau null)
+// tanpa credentials hanya bernilai untuk data publik, jadi severity-nya
+// rendah; kepercayaan subdomain / http:// butuh celah lain (XSS subdomain,
+// MITM) sehingga tanpa credentials diabaikan. ACAO "*" tidak dilaporkan:
+// browser menolak "*" bersama credentials.
+func Evaluate(p Probe, h http.Header) (Issue, bool) {
+	acao := strings.TrimSpace(h.Get("Access-Control-Allow-Origin"))
+	if acao == "" || acao == "*" || acao != p.Origin {
+		return Issue{}, false
+	}
+	creds := strings.EqualFold(strings.TrimSpace(h.Get("Access-Control-Allow-Credentials")), "true")
+	is := Issue{Probe: p, AllowOrigin: acao, Credentials: creds, Title: titles[p.Kind]}
+	switch p.Kind {
+	case KindArbitrary, KindNull, KindPrefix, KindSuffix, KindUnderscore:
+		is.Severity = findings.SevLow
+		if creds {
+			is.Severity = findings.SevHigh
+		}
+	case KindSubdomain, KindScheme:
+		if !creds {
+			return Issue{}, false
+		}
+		is.Severity = findings.SevMedium
+		if p.Kind == KindScheme {
+			is.Severity = findings.SevLow
+		}
+	default:
+		return Issue{}, false
+	}
+	if creds {
+		is.Title += " + credentials"
+	}
+	return is, true
+}
+
+// Checker mengirim semua probe ke satu URL.
+type Checker struct {
+	Client *probe.Client
+}
+
+// Check returns the unsafe combinations found for targetURL. Bila Origin
+// sembarang sudah dipantulkan, bypass prefix/suffix/subdomain tidak dikirim
+// (pasti ikut dipantulkan dan hanya menambah noise).
+func (c *Checker) Check(ctx context.Context, targetURL, root string) ([]Issue, error) {
+	var out []Issue
+	reflectsAny := false
+	for _, p := range Probes(targetURL, root) {
+		if reflectsAny && p.Kind != KindNull {
+			continue
+		}
+		resp, err := c.Client.Get(ctx, targetURL, http.Header{"Origin": {p.Origin}})
+		if err != nil {
+			if ctx.Err() != nil {
+				return out, ctx.Err()
+			}
+			return out, err
+		}
+		if is, ok := Evaluate(p, resp.Header); ok {
+			out = append(out, is)
+			reflectsAny = reflectsAny || p.Kind == KindArbitrary
+		}
+	}
+	return out, nil
+}
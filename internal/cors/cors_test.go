@@ -0,0 +1,90 @@
+package cors
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+	"github.com/D0Lv-1N/BUGx/internal/probe"
+)
+
+func TestEvaluate(t *testing.T) {
+	arb := Probe{KindArbitrary, "https://" + AttackerDomain}
+	sub := Probe{KindSubdomain, "https://bugx-test.example.com"}
+	tests := []struct {
+		name  string
+		p     Probe
+		acao  string
+		acac  string
+		want  bool
+		sev   string
+		creds bool
+	}{
+		{"reflected", arb, arb.Origin, "", true, findings.SevLow, false},
+		{"reflected+creds", arb, arb.Origin, "true", true, findings.SevHigh, true},
+		{"wildcard", arb, "*", "true", false, "", false},
+		{"other origin", arb, "https://example.com", "true", false, "", false},
+		{"subdomain no creds", sub, sub.Origin, "", false, "", false},
+		{"subdomain+creds", sub, sub.Origin, "TRUE", true, findings.SevMedium, true},
+	}
+	for _, tt := range tests {
+		h := http.Header{}
+		if tt.acao != "" {
+			h.Set("Access-Control-Allow-Origin", tt.acao)
+		}
+		if tt.acac != "" {
+			h.Set("Access-Control-Allow-Credentials", tt.acac)
+		}
+		is, ok := Evaluate(tt.p, h)
+		if ok != tt.want || is.Severity != tt.sev || is.Credentials != tt.creds {
+			t.Errorf("%s: Evaluate = %+v, %v", tt.name, is, ok)
+		}
+	}
+}
+
+// reflect memantulkan Origin apa pun bersama credentials.
+func reflect(w http.ResponseWriter, r *http.Request) {
+	if o := r.Header.Get("Origin"); o != "" {
+		w.Header().Set("Access-Control-Allow-Origin", o)
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// allowlist hanya mempercayai origin aplikasinya sendiri.
+func allowlist(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Origin") == "https://app.example.com" {
+		w.Header().Set("Access-Control-Allow-Origin", "https://app.example.com")
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vuln", reflect)
+	mux.HandleFunc("/safe", allowlist)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	chk := &Checker{Client: &probe.Client{HTTP: srv.Client()}}
+
+	issues, err := chk.Check(context.Background(), srv.URL+"/vuln", "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Origin sembarang sudah dipantulkan: hanya null yang masih dikirim.
+	if len(issues) != 2 || issues[0].Probe.Kind != KindArbitrary || issues[1].Probe.Kind != KindNull {
+		t.Fatalf("vuln: issues = %+v", issues)
+	}
+	if !issues[0].Credentials || issues[0].Severity != findings.SevHigh {
+		t.Errorf("vuln: %+v, want credentials + high", issues[0])
+	}
+
+	issues, err = chk.Check(context.Background(), srv.URL+"/safe", "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("safe: issues = %+v", issues)
+	}
+}
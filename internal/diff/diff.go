@@ -0,0 +1,128 @@
+// Package diff membandingkan aset recon dan temuan satu run dengan run
+// sebelumnya untuk domain yang sama ("baru sejak scan terakhir").
+package diff
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+)
+
+// sampleLimit adalah jumlah entri baru yang disimpan langsung di record run;
+// daftar lengkap ada di Assets.File.
+const sampleLimit = 20
+
+// Assets adalah perbandingan satu corpus (subs / hosts / URL).
+type Assets struct {
+	Total    int      `json:"total"`            // entri unik di run ini
+	New      int      `json:"new"`              // entri yang tidak ada di run sebelumnya
+	Baseline bool     `json:"baseline"`         // run sebelumnya punya corpus ini
+	File     string   `json:"file,omitempty"`   // path daftar lengkap entri baru
+	Sample   []string `json:"sample,omitempty"` // maksimal sampleLimit entri baru
+}
+
+// Run adalah ringkasan "baru sejak scan terakhir" satu run.
+type Run struct {
+	PrevID      string `json:"prev_id"` // run pembanding; kosong = belum ada run sebelumnya
+	Subs        Assets `json:"subs"`
+	Hosts       Assets `json:"hosts"`
+	URLs        Assets `json:"urls"`
+	NewFindings int    `json:"new_findings"`
+}
+
+// Lines membandingkan file baris-per-baris cur dengan prev dan menulis entri
+// baru ke out (bila out tidak kosong dan ada baseline). prev yang tidak ada berarti tidak ada
+// baseline: semua entri dihitung baru. cur yang tidak ada menghasilkan nilai
+// nol tanpa error.
+func Lines(prev, cur, out string) (a Assets, err error) {
+	seen := make(map[string]struct{})
+	if prev != "" {
+		err := eachLine(prev, func(l string) { seen[l] = struct{}{} })
+		switch {
+		case err == nil:
+			a.Baseline = true
+		case !os.IsNotExist(err):
+			return a, err
+		}
+	}
+
+	// Tanpa baseline semua entri baru; file salinan tidak perlu ditulis.
+	var w *bufio.Writer
+	if out != "" && a.Baseline {
+		f, err := os.Create(out)
+		if err != nil {
+			return a, err
+		}
+		w = bufio.NewWriter(f)
+		a.File = out
+		// Flush/Close yang gagal (mis. disk penuh) berarti new_*.txt terpotong.
+		defer func() {
+			if ferr := w.Flush(); err == nil {
+				err = ferr
+			}
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}()
+	}
+
+	dup := make(map[string]struct{})
+	err = eachLine(cur, func(l string) {
+		if _, ok := dup[l]; ok {
+			return
+		}
+		dup[l] = struct{}{}
+		a.Total++
+		if _, ok := seen[l]; ok {
+			return
+		}
+		a.New++
+		if len(a.Sample) < sampleLimit {
+			a.Sample = append(a.Sample, l)
+		}
+		if w != nil {
+			w.WriteString(l)
+			w.WriteByte('\n')
+		}
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return a, err
+	}
+	return a, nil
+}
+
+// MarkNew menandai temuan cur yang tidak ada di prev (berdasarkan
+// findings.Finding.Key) dan mengembalikan jumlahnya.
+func MarkNew(cur, prev []findings.Finding) int {
+	old := make(map[string]struct{}, len(prev))
+	for _, f := range prev {
+		old[f.Key()] = struct{}{}
+	}
+	n := 0
+	for i := range cur {
+		_, ok := old[cur[i].Key()]
+		cur[i].New = !ok
+		if cur[i].New {
+			n++
+		}
+	}
+	return n
+}
+
+func eachLine(path string, fn func(string)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 8*1024*1024)
+	for sc.Scan() {
+		if l := strings.TrimSpace(sc.Text()); l != "" {
+			fn(l)
+		}
+	}
+	return sc.Err()
+}
@@ -0,0 +1,163 @@
+package diff
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		prev     *string // nil = file prev tidak ada
+		cur      *string // nil = file cur tidak ada
+		want     Assets
+		wantFile string // isi new_*.txt; "" = file tidak ditulis
+	}{
+		{
+			name: "tanpa baseline semua baru",
+			cur:  ptr("a.com\nb.com\n"),
+			want: Assets{Total: 2, New: 2, Sample: []string{"a.com", "b.com"}},
+		},
+		{
+			name:     "entri baru saja",
+			prev:     ptr("a.com\n"),
+			cur:      ptr("a.com\nb.com\n"),
+			want:     Assets{Total: 2, New: 1, Baseline: true, Sample: []string{"b.com"}},
+			wantFile: "b.com\n",
+		},
+		{
+			name:     "duplikat dan spasi dihitung sekali",
+			prev:     ptr("a.com\n"),
+			cur:      ptr("b.com\n  b.com \n\na.com\nb.com\n"),
+			want:     Assets{Total: 2, New: 1, Baseline: true, Sample: []string{"b.com"}},
+			wantFile: "b.com\n",
+		},
+		{
+			name:     "tidak ada yang baru",
+			prev:     ptr("a.com\nb.com\n"),
+			cur:      ptr("b.com\n"),
+			want:     Assets{Total: 1, Baseline: true},
+			wantFile: "\x00",
+		},
+		{
+			name:     "cur tidak ada",
+			prev:     ptr("a.com\n"),
+			want:     Assets{Baseline: true},
+			wantFile: "\x00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			prev := filepath.Join(dir, "prev.txt")
+			cur := filepath.Join(dir, "cur.txt")
+			out := filepath.Join(dir, "new.txt")
+			if tt.prev != nil {
+				writeFile(t, prev, *tt.prev)
+			}
+			if tt.cur != nil {
+				writeFile(t, cur, *tt.cur)
+			}
+
+			got, err := Lines(prev, cur, out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := tt.want
+			if want.Baseline {
+				want.File = out
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("Lines = %+v, want %+v", got, want)
+			}
+
+			data, err := os.ReadFile(out)
+			switch {
+			case tt.wantFile == "":
+				if !os.IsNotExist(err) {
+					t.Fatalf("new.txt ditulis tanpa baseline (err=%v)", err)
+				}
+			case tt.wantFile == "\x00":
+				if err != nil || len(data) != 0 {
+					t.Fatalf("new.txt = %q, %v; want kosong", data, err)
+				}
+			case string(data) != tt.wantFile:
+				t.Fatalf("new.txt = %q, want %q", data, tt.wantFile)
+			}
+		})
+	}
+}
+
+func TestLinesSampleLimit(t *testing.T) {
+	dir := t.TempDir()
+	cur := filepath.Join(dir, "cur.txt")
+	var data []byte
+	for i := 0; i < sampleLimit+5; i++ {
+		data = append(data, byte('a'+i%26), byte('a'+i/26), '\n')
+	}
+	writeFile(t, cur, string(data))
+
+	got, err := Lines("", cur, filepath.Join(dir, "new.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.New != sampleLimit+5 || len(got.Sample) != sampleLimit {
+		t.Fatalf("New = %d, len(Sample) = %d", got.New, len(got.Sample))
+	}
+}
+
+func TestMarkNew(t *testing.T) {
+	xss := findings.Finding{Tool: "dalfox", ID: "V", URL: "https://a.com/?q=1", Param: "q"}
+	sqli := findings.Finding{Tool: "nuclei", ID: "sqli-error", URL: "https://a.com/item"}
+	xssOtherParam := xss
+	xssOtherParam.Param = "s"
+	xssLater := xss
+	xssLater.Evidence = "payload lain"
+
+	tests := []struct {
+		name    string
+		cur     []findings.Finding
+		prev    []findings.Finding
+		wantNew []bool
+	}{
+		{"tanpa run sebelumnya", []findings.Finding{xss, sqli}, nil, []bool{true, true}},
+		{"temuan sama tidak baru", []findings.Finding{xss, sqli}, []findings.Finding{sqli}, []bool{true, false}},
+		{"evidence berbeda tetap temuan sama", []findings.Finding{xssLater}, []findings.Finding{xss}, []bool{false}},
+		{"param berbeda baru", []findings.Finding{xssOtherParam}, []findings.Finding{xss}, []bool{true}},
+		{"cur kosong", nil, []findings.Finding{xss}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cur := append([]findings.Finding(nil), tt.cur...)
+			for i := range cur {
+				cur[i].New = !tt.wantNew[i] // pastikan nilai lama ditimpa
+			}
+			n := MarkNew(cur, tt.prev)
+			want := 0
+			for i, f := range cur {
+				if f.New != tt.wantNew[i] {
+					t.Errorf("cur[%d].New = %v, want %v", i, f.New, tt.wantNew[i])
+				}
+				if tt.wantNew[i] {
+					want++
+				}
+			}
+			if n != want {
+				t.Errorf("MarkNew = %d, want %d", n, want)
+			}
+		})
+	}
+}
+
+func ptr(s string) *string { return &s }
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,45 @@
+package findings
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// ToolNative is the parser name for output of BUGx's built-in checks
+// (takeover, ...): JSONL berisi Finding apa adanya.
+const ToolNative = "bugx"
+
+// ParseNative parses JSONL written by WriteNative. Baris yang tidak valid
+// dilewati.
+func ParseNative(r io.Reader) ([]Finding, error) {
+	var out []Finding
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 8*1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+		var f Finding
+		if err := json.Unmarshal([]byte(line), &f); err != nil || f.URL == "" {
+			continue
+		}
+		f.Severity = NormalizeSeverity(f.Severity)
+		out = append(out, f)
+	}
+	return out, sc.Err()
+}
+
+// WriteNative writes findings as JSONL (satu Finding per baris).
+func WriteNative(w io.Writer, list []Finding) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, f := range list {
+		if err := enc.Encode(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
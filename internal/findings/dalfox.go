@@ -0,0 +1,135 @@
+package findings
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// dalfoxRecord is one PoC from dalfox --format json.
+type dalfoxRecord struct {
+	Type       string `json:"type"` // V (verified), R (reflected), G (grep)
+	InjectType string `json:"inject_type"`
+	Method     string `json:"method"`
+	Data       string `json:"data"` // URL PoC
+	Param      string `json:"param"`
+	Payload    string `json:"payload"`
+	Evidence   string `json:"evidence"`
+	CWE        string `json:"cwe"`
+	Severity   string `json:"severity"`
+}
+
+// dalfoxPlainRe matches dalfox's text PoC lines: [POC][V][GET][inHTML] https://...
+var dalfoxPlainRe = regexp.MustCompile(`^\[POC\]\[([A-Z])\]\[([A-Z]+)\]\[([^\]]*)\]\s+(\S+)`)
+
+// ParseDalfox parses dalfox output: JSON array, JSONL, atau teks [POC].
+func ParseDalfox(r io.Reader) ([]Finding, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+
+	if trimmed[0] == '[' && !dalfoxPlainRe.Match(trimmed) {
+		var recs []dalfoxRecord
+		if err := json.Unmarshal(trimmed, &recs); err == nil {
+			out := make([]Finding, 0, len(recs))
+			for _, rec := range recs {
+				if rec.Data == "" {
+					continue
+				}
+				out = append(out, rec.finding())
+			}
+			return out, nil
+		}
+	}
+
+	var out []Finding
+	sc := bufio.NewScanner(bytes.NewReader(trimmed))
+	sc.Buffer(make([]byte, 0, 64*1024), 8*1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		// Array JSON yang terpotong (dalfox dihentikan) dibaca per baris:
+		// buang "[" / "," / "]" di tepi objek.
+		obj := strings.TrimRight(strings.TrimLeft(line, "[, "), "], ")
+		switch {
+		case strings.HasPrefix(obj, "{"):
+			var rec dalfoxRecord
+			if err := json.Unmarshal([]byte(obj), &rec); err == nil && rec.Data != "" {
+				out = append(out, rec.finding())
+			}
+		case dalfoxPlainRe.MatchString(line):
+			m := dalfoxPlainRe.FindStringSubmatch(line)
+			out = append(out, Finding{
+				Tool:     "dalfox",
+				ID:       "dalfox-" + dalfoxTypeName(m[1]),
+				Name:     "XSS (" + dalfoxTypeName(m[1]) + ", " + m[3] + ")",
+				Severity: dalfoxSeverity("", m[1]),
+				URL:      m[4],
+			})
+		}
+	}
+	return out, sc.Err()
+}
+
+func (rec dalfoxRecord) finding() Finding {
+	evidence := rec.Evidence
+	if rec.Payload != "" {
+		if evidence != "" {
+			evidence = "payload " + rec.Payload + " | " + evidence
+		} else {
+			evidence = "payload " + rec.Payload
+		}
+	}
+	name := "XSS (" + dalfoxTypeName(rec.Type)
+	if rec.InjectType != "" {
+		name += ", " + rec.InjectType
+	}
+	name += ")"
+	return Finding{
+		Tool:     "dalfox",
+		ID:       "dalfox-" + dalfoxTypeName(rec.Type),
+		Name:     name,
+		Severity: dalfoxSeverity(rec.Severity, rec.Type),
+		URL:      rec.Data,
+		Param:    rec.Param,
+		Evidence: evidence,
+	}
+}
+
+// dalfoxSeverity: pakai severity dari dalfox bila ada, jika tidak turunkan
+// dari tipe PoC (verified > reflected > grep).
+func dalfoxSeverity(sev, typ string) string {
+	if s := NormalizeSeverity(sev); s != SevUnknown {
+		return s
+	}
+	switch typ {
+	case "V":
+		return SevHigh
+	case "R":
+		return SevMedium
+	case "G":
+		return SevInfo
+	default:
+		return SevUnknown
+	}
+}
+
+func dalfoxTypeName(typ string) string {
+	switch typ {
+	case "V":
+		return "verified"
+	case "R":
+		return "reflected"
+	case "G":
+		return "grep"
+	default:
+		return strings.ToLower(typ)
+	}
+}
@@ -0,0 +1,177 @@
+package findings
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Severity levels, ordered from most to least severe.
+const (
+	SevCritical = "critical"
+	SevHigh     = "high"
+	SevMedium   = "medium"
+	SevLow      = "low"
+	SevInfo     = "info"
+	SevUnknown  = "unknown"
+)
+
+// Severities lists all levels in display order (paling parah dulu).
+var Severities = []string{SevCritical, SevHigh, SevMedium, SevLow, SevInfo, SevUnknown}
+
+// Finding adalah satu temuan terstruktur dari tool mana pun.
+type Finding struct {
+	Tool      string    `json:"tool"`
+	Mode      string    `json:"mode,omitempty"`
+	ID        string    `json:"id"`             // template-id nuclei / tipe dalfox
+	Name      string    `json:"name,omitempty"` // judul yang bisa dibaca manusia
+	Severity  string    `json:"severity"`
+	URL       string    `json:"url"`
+	Param     string    `json:"param,omitempty"`
+	Evidence  string    `json:"evidence,omitempty"`
+	Request   string    `json:"request,omitempty"` // raw HTTP request bila tool menyediakan
+	Curl      string    `json:"curl,omitempty"`    // perintah curl untuk reproduksi
+	Timestamp time.Time `json:"timestamp"`
+	New       bool      `json:"new,omitempty"` // tidak ada di run sebelumnya (diff)
+}
+
+// Key identifies the same finding across runs (tanpa timestamp / evidence).
+func (f Finding) Key() string {
+	return f.Tool + "\x00" + f.ID + "\x00" + f.URL + "\x00" + f.Param
+}
+
+// ParseFunc parses one tool's output stream into findings.
+type ParseFunc func(r io.Reader) ([]Finding, error)
+
+// parsers maps tool names to their output parser.
+var parsers = map[string]ParseFunc{
+	"nuclei":   ParseNuclei,
+	"dalfox":   ParseDalfox,
+	ToolNative: ParseNative,
+}
+
+// ParserFor returns the parser for tool, or nil if the tool has none.
+func ParserFor(tool string) ParseFunc {
+	return parsers[strings.ToLower(tool)]
+}
+
+// ParseFile parses the output file of tool. File yang tidak ada atau kosong
+// menghasilkan nil tanpa error.
+func ParseFile(tool, path string) ([]Finding, error) {
+	parse := ParserFor(tool)
+	if parse == nil {
+		return nil, fmt.Errorf("tidak ada parser untuk tool %s", tool)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	list, err := parse(f)
+	if err != nil {
+		return nil, err
+	}
+	if ts := fileTime(f); !ts.IsZero() {
+		for i := range list {
+			if list[i].Timestamp.IsZero() {
+				list[i].Timestamp = ts
+			}
+		}
+	}
+	return list, nil
+}
+
+// NormalizeSeverity maps tool-specific severity strings to the shared levels.
+func NormalizeSeverity(s string) string {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "critical", "crit":
+		return SevCritical
+	case "high":
+		return SevHigh
+	case "medium", "med", "moderate":
+		return SevMedium
+	case "low":
+		return SevLow
+	case "info", "informational", "information":
+		return SevInfo
+	default:
+		return SevUnknown
+	}
+}
+
+// Rank returns a sortable weight for severity (critical tertinggi).
+func Rank(severity string) int {
+	switch NormalizeSeverity(severity) {
+	case SevCritical:
+		return 5
+	case SevHigh:
+		return 4
+	case SevMedium:
+		return 3
+	case SevLow:
+		return 2
+	case SevInfo:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// SortBySeverity sorts findings in place: severity desc, lalu mode, URL.
+func SortBySeverity(list []Finding) {
+	sort.SliceStable(list, func(i, j int) bool {
+		ri, rj := Rank(list[i].Severity), Rank(list[j].Severity)
+		if ri != rj {
+			return ri > rj
+		}
+		if list[i].Mode != list[j].Mode {
+			return list[i].Mode < list[j].Mode
+		}
+		return list[i].URL < list[j].URL
+	})
+}
+
+// CountBySeverity returns the number of findings per severity level.
+func CountBySeverity(list []Finding) map[string]int {
+	counts := make(map[string]int, len(Severities))
+	for _, f := range list {
+		counts[NormalizeSeverity(f.Severity)]++
+	}
+	return counts
+}
+
+// AtLeast returns findings with severity >= min (urutan asli dipertahankan).
+func AtLeast(list []Finding, min string) []Finding {
+	var out []Finding
+	for _, f := range list {
+		if Rank(f.Severity) >= Rank(min) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// Top returns the n most severe findings (list asli tidak diubah).
+func Top(list []Finding, n int) []Finding {
+	cp := append([]Finding(nil), list...)
+	SortBySeverity(cp)
+	if n >= 0 && len(cp) > n {
+		cp = cp[:n]
+	}
+	return cp
+}
+
+func fileTime(f *os.File) time.Time {
+	info, err := f.Stat()
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,90 @@
+package findings
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// nucleiRecord is the subset of nuclei -jsonl output that BUGx uses.
+type nucleiRecord struct {
+	TemplateID string `json:"template-id"`
+	Info       struct {
+		Name     string `json:"name"`
+		Severity string `json:"severity"`
+	} `json:"info"`
+	Host             string   `json:"host"`
+	MatchedAt        string   `json:"matched-at"`
+	MatcherName      string   `json:"matcher-name"`
+	ExtractedResults []string `json:"extracted-results"`
+	Timestamp        string   `json:"timestamp"`
+	Request          string   `json:"request"`
+	CurlCommand      string   `json:"curl-command"`
+}
+
+// nucleiPlainRe matches nuclei's default text output:
+// [template-id:matcher] [http] [high] https://target/path ["extra"]
+var nucleiPlainRe = regexp.MustCompile(`^\[([^\]]+)\]\s+\[([^\]]+)\]\s+\[([^\]]+)\]\s+(\S+)\s*(.*)$`)
+
+// ParseNuclei parses nuclei output: JSONL (-jsonl) atau format teks default.
+// Baris yang tidak dikenali dilewati.
+func ParseNuclei(r io.Reader) ([]Finding, error) {
+	var out []Finding
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 8*1024*1024)
+
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "{") {
+			var rec nucleiRecord
+			if err := json.Unmarshal([]byte(line), &rec); err != nil {
+				continue
+			}
+			out = append(out, rec.finding())
+			continue
+		}
+		if m := nucleiPlainRe.FindStringSubmatch(line); m != nil {
+			id, matcher, _ := strings.Cut(m[1], ":")
+			out = append(out, Finding{
+				Tool:     "nuclei",
+				ID:       id,
+				Name:     id,
+				Severity: NormalizeSeverity(m[3]),
+				URL:      m[4],
+				Param:    matcher,
+				Evidence: strings.TrimSpace(m[5]),
+			})
+		}
+	}
+	return out, sc.Err()
+}
+
+func (rec nucleiRecord) finding() Finding {
+	f := Finding{
+		Tool:     "nuclei",
+		ID:       rec.TemplateID,
+		Name:     rec.Info.Name,
+		Severity: NormalizeSeverity(rec.Info.Severity),
+		URL:      rec.MatchedAt,
+		Param:    rec.MatcherName,
+		Evidence: strings.Join(rec.ExtractedResults, ", "),
+		Request:  rec.Request,
+		Curl:     rec.CurlCommand,
+	}
+	if f.URL == "" {
+		f.URL = rec.Host
+	}
+	if f.Name == "" {
+		f.Name = f.ID
+	}
+	if ts, err := time.Parse(time.RFC3339Nano, rec.Timestamp); err == nil {
+		f.Timestamp = ts
+	}
+	return f
+}
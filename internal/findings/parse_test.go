@@ -0,0 +1,225 @@
+package findings
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const nucleiJSONL = `{"template":"http/vulnerabilities/generic/error-based-sqli.yaml","template-id":"error-based-sqli","info":{"name":"Error based SQL injection","author":["geeknik"],"tags":["sqli","generic"],"severity":"CRITICAL"},"type":"http","host":"https://shop.example.com","matched-at":"https://shop.example.com/item?id=1'","extracted-results":["You have an error in your SQL syntax"],"request":"GET /item?id=1' HTTP/1.1\r\nHost: shop.example.com\r\n\r\n","ip":"93.184.216.34","timestamp":"2026-10-16T20:25:12.123456789+07:00","curl-command":"curl -X 'GET' 'https://shop.example.com/item?id=1%27'","matcher-status":true}
+{"template-id":"tech-detect","info":{"name":"Wappalyzer Technology Detection","severity":"info"},"type":"http","host":"https://www.example.com","matched-at":"https://www.example.com","matcher-name":"nginx","timestamp":"2026-10-16T20:25:13Z","matcher-status":true}
+{"template-id":"missing-name","info":{"severity":"moderate"},"host":"api.example.com","timestamp":"bukan waktu"}
+
+{"template-id":"open-redirect","info":{"name":"Open Redirect","severity":"medium"},"matched-at":"https://www.example.com/r?u=evil.com","times`
+
+func TestParseNucleiJSONL(t *testing.T) {
+	got, err := ParseNuclei(strings.NewReader(nucleiJSONL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("len = %d, want 3 (baris terakhir terpotong dilewati): %+v", len(got), got)
+	}
+
+	sqli := got[0]
+	if sqli.Tool != "nuclei" || sqli.ID != "error-based-sqli" || sqli.Name != "Error based SQL injection" {
+		t.Errorf("sqli = %+v", sqli)
+	}
+	if sqli.Severity != SevCritical {
+		t.Errorf("Severity = %q, want %q", sqli.Severity, SevCritical)
+	}
+	if sqli.URL != "https://shop.example.com/item?id=1'" {
+		t.Errorf("URL = %q", sqli.URL)
+	}
+	if sqli.Evidence != "You have an error in your SQL syntax" || !strings.HasPrefix(sqli.Request, "GET /item") ||
+		!strings.HasPrefix(sqli.Curl, "curl -X") {
+		t.Errorf("evidence/request/curl = %q / %q / %q", sqli.Evidence, sqli.Request, sqli.Curl)
+	}
+	if want := time.Date(2026, 10, 16, 13, 25, 12, 123456789, time.UTC); !sqli.Timestamp.Equal(want) {
+		t.Errorf("Timestamp = %v, want %v", sqli.Timestamp, want)
+	}
+
+	if tech := got[1]; tech.Severity != SevInfo || tech.Param != "nginx" {
+		t.Errorf("tech = %+v", tech)
+	}
+
+	// Tanpa matched-at / nama / timestamp valid: pakai host, template-id, nol.
+	m := got[2]
+	if m.URL != "api.example.com" || m.Name != "missing-name" || m.Severity != SevMedium || !m.Timestamp.IsZero() {
+		t.Errorf("missing-name = %+v", m)
+	}
+}
+
+func TestParseNucleiPlain(t *testing.T) {
+	out := "[INF] Current nuclei version: v3.3.5\n" +
+		"[git-config:status] [http] [medium] https://www.example.com/.git/config\n" +
+		"[tech-detect:nginx] [http] [info] https://www.example.com [\"1.25.3\"]\n" +
+		"[cve-2021-44228] [http] [CRITICAL] https://api.example.com/login\n"
+	got, err := ParseNuclei(strings.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("len = %d, want 3: %+v", len(got), got)
+	}
+	if got[0].ID != "git-config" || got[0].Param != "status" || got[0].Severity != SevMedium {
+		t.Errorf("got[0] = %+v", got[0])
+	}
+	if got[1].Evidence != `["1.25.3"]` {
+		t.Errorf("Evidence = %q", got[1].Evidence)
+	}
+	if got[2].ID != "cve-2021-44228" || got[2].Param != "" || got[2].Severity != SevCritical {
+		t.Errorf("got[2] = %+v", got[2])
+	}
+}
+
+const dalfoxJSONArray = `[{"type":"V","inject_type":"inHTML-URL","poc_type":"plain","method":"GET","data":"https://www.example.com/search?q=%3CsVg%2Fonload%3Dalert%2845%29%3E","param":"q","payload":"<sVg/onload=alert(45)>","evidence":"48 line:  <p>Results for <sVg/onload=alert(45)></p>","cwe":"CWE-79","severity":"High","message_id":412,"message_str":"Triggered XSS Payload (found DOM Object): q=<sVg/onload=alert(45)>"}
+,{"type":"R","inject_type":"inJS-single","poc_type":"plain","method":"GET","data":"https://www.example.com/p?id=1%27-alert(1)-%27","param":"id","payload":"'-alert(1)-'","evidence":"","cwe":"CWE-79","severity":"","message_id":0,"message_str":"Reflected Payload in JS: id='-alert(1)-'"}
+,{"type":"G","inject_type":"","poc_type":"","method":"GET","data":"","param":"","payload":"","evidence":"","cwe":"","severity":"","message_id":0,"message_str":""}
+]`
+
+func TestParseDalfoxJSONArray(t *testing.T) {
+	got, err := ParseDalfox(strings.NewReader(dalfoxJSONArray))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("len = %d, want 2 (record tanpa data dilewati): %+v", len(got), got)
+	}
+	v := got[0]
+	if v.Tool != "dalfox" || v.ID != "dalfox-verified" || v.Name != "XSS (verified, inHTML-URL)" ||
+		v.Severity != SevHigh || v.Param != "q" {
+		t.Errorf("verified = %+v", v)
+	}
+	if !strings.HasPrefix(v.Evidence, "payload <sVg/onload=alert(45)> | 48 line:") {
+		t.Errorf("Evidence = %q", v.Evidence)
+	}
+	// Severity kosong diturunkan dari tipe PoC.
+	if r := got[1]; r.ID != "dalfox-reflected" || r.Severity != SevMedium || r.Evidence != "payload '-alert(1)-'" {
+		t.Errorf("reflected = %+v", r)
+	}
+}
+
+func TestParseDalfoxTruncatedArray(t *testing.T) {
+	// dalfox dihentikan di tengah penulisan: array tidak ditutup.
+	cut := dalfoxJSONArray[:strings.Index(dalfoxJSONArray, `,{"type":"G"`)+20]
+	got, err := ParseDalfox(strings.NewReader(cut))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].ID != "dalfox-verified" || got[1].ID != "dalfox-reflected" {
+		t.Fatalf("got = %+v", got)
+	}
+}
+
+func TestParseDalfoxJSONL(t *testing.T) {
+	out := `{"type":"V","inject_type":"inATTR-double","method":"GET","data":"https://a.example.com/?n=%22onmouseover%3Dalert(1)","param":"n","payload":"\"onmouseover=alert(1)","severity":"critical"}
+{"type":"G","inject_type":"BAV/OR","method":"GET","data":"https://a.example.com/?next=//evil.com","param":"next","severity":"Low"}
+{"type":"R","method":"GET","data":"https://a.example.com/?x=1","param":"x","payl`
+	got, err := ParseDalfox(strings.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("len = %d, want 2 (baris terakhir terpotong dilewati): %+v", len(got), got)
+	}
+	if got[0].Severity != SevCritical || got[1].Severity != SevLow || got[1].ID != "dalfox-grep" {
+		t.Errorf("got = %+v", got)
+	}
+}
+
+func TestParseDalfoxPlain(t *testing.T) {
+	out := `
+    _..._
+  .' .::::.   __   _   _    ___ _ __ __
+ :  :::::::: |  \ / \ | |  | __/ \\ V /
+
+ 🎯  Target                 https://www.example.com/search?q=1
+[I] Found 1 testing point in DOM base parameter mining
+[V] Triggered XSS Payload (found DOM Object): q=<sVg/onload=alert(45)>
+[POC][V][GET][inHTML-URL] https://www.example.com/search?q=%3CsVg%2Fonload%3Dalert%2845%29%3E
+[POC][R][GET][inJS-single] https://www.example.com/p?id=1%27-alert(1)-%27
+[POC][G][GET][BAV/OR] https://www.example.com/?next=//evil.com
+`
+	got, err := ParseDalfox(strings.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct{ id, sev, name string }{
+		{"dalfox-verified", SevHigh, "XSS (verified, inHTML-URL)"},
+		{"dalfox-reflected", SevMedium, "XSS (reflected, inJS-single)"},
+		{"dalfox-grep", SevInfo, "XSS (grep, BAV/OR)"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("len = %d, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].ID != w.id || got[i].Severity != w.sev || got[i].Name != w.name {
+			t.Errorf("got[%d] = %+v, want %+v", i, got[i], w)
+		}
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	for name, parse := range map[string]ParseFunc{"nuclei": ParseNuclei, "dalfox": ParseDalfox, ToolNative: ParseNative} {
+		for _, in := range []string{"", "\n\n  \n"} {
+			got, err := parse(strings.NewReader(in))
+			if err != nil || len(got) != 0 {
+				t.Errorf("%s(%q) = %v, %v; want kosong", name, in, got, err)
+			}
+		}
+	}
+	for _, in := range []string{"[]", "[]\n"} {
+		if got, err := ParseDalfox(strings.NewReader(in)); err != nil || len(got) != 0 {
+			t.Errorf("ParseDalfox(%q) = %v, %v", in, got, err)
+		}
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	dir := t.TempDir()
+	if got, err := ParseFile("nuclei", filepath.Join(dir, "tidak-ada.txt")); err != nil || got != nil {
+		t.Errorf("file tidak ada = %v, %v", got, err)
+	}
+	if _, err := ParseFile("sqlmap", filepath.Join(dir, "x.txt")); err == nil {
+		t.Error("tool tanpa parser harus error")
+	}
+
+	path := filepath.Join(dir, "nuclei.txt")
+	if err := os.WriteFile(path, []byte("[git-config] [http] [medium] https://www.example.com/.git/config\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParseFile("NUCLEI", path)
+	if err != nil || len(got) != 1 {
+		t.Fatalf("ParseFile = %v, %v", got, err)
+	}
+	// Format teks tidak punya timestamp: dipakai waktu file.
+	if !got[0].Timestamp.Equal(mtime) {
+		t.Errorf("Timestamp = %v, want %v", got[0].Timestamp, mtime)
+	}
+}
+
+func TestNormalizeSeverity(t *testing.T) {
+	for in, want := range map[string]string{
+		"CRITICAL":      SevCritical,
+		" crit ":        SevCritical,
+		"High":          SevHigh,
+		"moderate":      SevMedium,
+		"med":           SevMedium,
+		"low":           SevLow,
+		"Informational": SevInfo,
+		"information":   SevInfo,
+		"":              SevUnknown,
+		"severe":        SevUnknown,
+	} {
+		if got := NormalizeSeverity(in); got != want {
+			t.Errorf("NormalizeSeverity(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
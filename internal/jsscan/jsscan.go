@@ -0,0 +1,109 @@
+// Package jsscan mengunduh file JavaScript dan mengekstrak endpoint / path
+// API serta secret yang ter-hardcode (AWS, Google, Slack, JWT, private key)
+// dengan rule regex bawaan plus cek entropi.
+package jsscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/D0Lv-1N/BUGx/internal/probe"
+)
+
+// DefaultMaxBytes membatasi ukuran satu file JS yang dibaca (bundle besar
+// dipotong, bukan dilewati).
+const DefaultMaxBytes = 5 << 20
+
+// ErrNotJS dikembalikan Fetch bila URL tidak (lagi) menyajikan JavaScript:
+// status bukan 200 atau halaman HTML (soft 404).
+var ErrNotJS = errors.New("bukan file JavaScript")
+
+// Secret adalah satu nilai yang cocok dengan Rule.
+type Secret struct {
+	Rule  *Rule
+	Value string
+}
+
+// Result adalah hasil ekstraksi satu file.
+type Result struct {
+	Endpoints []string // unik, terurut
+	Secrets   []Secret // unik per nilai, urutan Rules
+}
+
+// endpointRe: string literal yang berisi URL absolut, path absolut, atau
+// path relatif dengan ekstensi server-side / segmen API (gaya LinkFinder).
+var endpointRe = regexp.MustCompile(`["'\x60]((?:https?:)?//[A-Za-z0-9.\-]+\.[A-Za-z]{2,}(?::[0-9]+)?(?:/[^"'\x60\s<>]*)?|/[A-Za-z0-9_\-.~][^"'\x60\s<>]*|[A-Za-z0-9_\-./]+\.(?:php|aspx?|jsp|json|action|do|cgi|xml)(?:\?[^"'\x60\s<>]*)?|(?:api|rest|graphql|v[0-9]+)/[A-Za-z0-9_\-./?=&{}:]+)["'\x60]`)
+
+// staticExt: aset statis yang bukan endpoint menarik.
+var staticExt = regexp.MustCompile(`(?i)\.(?:png|jpe?g|gif|svg|webp|ico|bmp|css|woff2?|ttf|eot|otf|mp4|mp3|webm|map)(?:[?#].*)?$`)
+
+// maxEndpointLen: literal lebih panjang biasanya data / template, bukan path.
+const maxEndpointLen = 256
+
+// Extract menjalankan ekstraksi endpoint dan semua Rules pada body.
+func Extract(body []byte) Result {
+	text := string(body)
+	var res Result
+
+	seen := make(map[string]bool)
+	for _, m := range endpointRe.FindAllStringSubmatch(text, -1) {
+		ep := m[1]
+		if len(ep) > maxEndpointLen || seen[ep] || staticExt.MatchString(ep) || strings.HasPrefix(ep, "//#") {
+			continue
+		}
+		seen[ep] = true
+		res.Endpoints = append(res.Endpoints, ep)
+	}
+	sort.Strings(res.Endpoints)
+
+	values := make(map[string]bool)
+	for i := range Rules {
+		r := &Rules[i]
+		for _, m := range r.Re.FindAllStringSubmatch(text, -1) {
+			v := m[r.Group]
+			if values[v] || !r.plausible(v) {
+				continue
+			}
+			values[v] = true
+			res.Secrets = append(res.Secrets, Secret{Rule: r, Value: v})
+		}
+	}
+	return res
+}
+
+// Mask menyamarkan nilai secret untuk laporan: "AKIA…WXYZ". Header PEM
+// private key bukan secret, jadi ditampilkan apa adanya.
+func Mask(v string) string {
+	switch {
+	case strings.HasPrefix(v, "-----BEGIN"):
+		return v
+	case len(v) <= 12:
+		return strings.Repeat("*", len(v))
+	}
+	return v[:4] + "…" + v[len(v)-4:]
+}
+
+// Fetcher mengunduh file JS. Client.MaxBody sebaiknya DefaultMaxBytes.
+type Fetcher struct {
+	Client *probe.Client
+}
+
+// Fetch returns body file JS di url, atau ErrNotJS.
+func (f *Fetcher) Fetch(ctx context.Context, url string) ([]byte, error) {
+	resp, err := f.Client.Get(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status != http.StatusOK {
+		return nil, fmt.Errorf("%w (status %d)", ErrNotJS, resp.Status)
+	}
+	if ct := resp.Header.Get("Content-Type"); strings.Contains(strings.ToLower(ct), "text/html") {
+		return nil, fmt.Errorf("%w (%s)", ErrNotJS, ct)
+	}
+	return resp.Body, nil
+}
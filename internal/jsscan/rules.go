@@ -0,0 +1,101 @@
+package jsscan
+
+import (
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+)
+
+// Rule adalah satu pola secret. Group memilih capture group yang berisi
+// nilai secret (0 = seluruh match); MinEntropy (bit per karakter, 0 = tanpa
+// cek) menyaring placeholder seperti "xxxxxxxx" atau "your_api_key_here".
+type Rule struct {
+	ID         string
+	Name       string
+	Severity   string
+	Re         *regexp.Regexp
+	Group      int
+	MinEntropy float64
+}
+
+// Rules adalah rule bawaan. Urutan penting: rule spesifik dulu, supaya
+// nilai yang sama tidak dilaporkan ulang oleh rule generic.
+var Rules = []Rule{
+	{
+		ID: "private-key", Name: "Private key", Severity: findings.SevCritical,
+		Re: regexp.MustCompile(`-----BEGIN (?:RSA |EC |DSA |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY(?: BLOCK)?-----`),
+	},
+	{
+		ID: "aws-access-key", Name: "AWS Access Key ID", Severity: findings.SevHigh,
+		Re: regexp.MustCompile(`\b(?:AKIA|ASIA|AGPA|AIDA|AROA)[0-9A-Z]{16}\b`),
+	},
+	{
+		ID: "aws-secret-key", Name: "AWS Secret Access Key", Severity: findings.SevCritical,
+		Re:    regexp.MustCompile(`(?i)aws.{0,20}(?:secret|private).{0,20}["'\x60]([A-Za-z0-9/+=]{40})["'\x60]`),
+		Group: 1, MinEntropy: 4.0,
+	},
+	{
+		ID: "google-api-key", Name: "Google API key", Severity: findings.SevMedium,
+		Re: regexp.MustCompile(`\bAIza[0-9A-Za-z_\-]{35}\b`),
+	},
+	{
+		ID: "slack-token", Name: "Slack token", Severity: findings.SevHigh,
+		Re: regexp.MustCompile(`\bxox[abposr]-[0-9A-Za-z\-]{10,72}\b`),
+	},
+	{
+		ID: "slack-webhook", Name: "Slack webhook", Severity: findings.SevMedium,
+		Re: regexp.MustCompile(`https://hooks\.slack\.com/services/T[0-9A-Za-z_]+/B[0-9A-Za-z_]+/[0-9A-Za-z]+`),
+	},
+	{
+		ID: "github-token", Name: "GitHub token", Severity: findings.SevHigh,
+		Re: regexp.MustCompile(`\b(?:ghp|gho|ghu|ghs|ghr)_[0-9A-Za-z]{36}\b|\bgithub_pat_[0-9A-Za-z_]{82}\b`),
+	},
+	{
+		ID: "stripe-secret-key", Name: "Stripe secret key", Severity: findings.SevCritical,
+		Re: regexp.MustCompile(`\b(?:sk|rk)_live_[0-9A-Za-z]{24,99}\b`),
+	},
+	{
+		ID: "jwt", Name: "JSON Web Token", Severity: findings.SevMedium,
+		Re:         regexp.MustCompile(`\beyJ[A-Za-z0-9_\-]{10,}\.eyJ[A-Za-z0-9_\-]{10,}\.[A-Za-z0-9_\-]{10,}`),
+		MinEntropy: 4.0,
+	},
+	{
+		ID: "generic-secret", Name: "Hardcoded secret", Severity: findings.SevMedium,
+		Re:    regexp.MustCompile(`(?i)["']?(?:api[_-]?key|apikey|secret[_-]?key|client[_-]?secret|access[_-]?token|auth[_-]?token|private[_-]?key|passw(?:or)?d)["']?\s*[:=]\s*["'\x60]([^"'\x60\s]{12,128})["'\x60]`),
+		Group: 1, MinEntropy: 3.5,
+	},
+}
+
+// placeholders: nilai contoh dari dokumentasi yang sering ikut di bundle.
+var placeholders = []string{"EXAMPLE", "example", "xxxxxx", "XXXXXX", "your_", "YOUR_", "<", "${", "{{"}
+
+// Entropy returns entropi Shannon s dalam bit per karakter.
+func Entropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	n := 0
+	for _, r := range s {
+		counts[r]++
+		n++
+	}
+	var h float64
+	for _, c := range counts {
+		p := float64(c) / float64(n)
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+// plausible menyaring nilai placeholder dan nilai dengan entropi rendah.
+func (r *Rule) plausible(value string) bool {
+	for _, p := range placeholders {
+		if strings.Contains(value, p) {
+			return false
+		}
+	}
+	return r.MinEntropy <= 0 || Entropy(value) >= r.MinEntropy
+}
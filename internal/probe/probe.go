@@ -0,0 +1,74 @@
+// Package probe adalah client HTTP bersama untuk cek native (cors, crlf,
+// ssti, js, takeover): satu tempat untuk header auth, rate limit dan batas
+// body, supaya setiap paket cek hanya berisi payload dan penilaiannya.
+package probe
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// DefaultMaxBody adalah batas body yang dibaca bila Client.MaxBody 0.
+const DefaultMaxBody = 1 << 20
+
+// Client mengirim request GET ke target.
+type Client struct {
+	HTTP *http.Client // nil = http.DefaultClient
+	// Prepare (opsional) melengkapi request, mis. header auth.
+	Prepare func(req *http.Request)
+	// BeforeRequest (opsional) dipanggil sebelum setiap request, mis. untuk
+	// rate limit. Error-nya dikembalikan apa adanya.
+	BeforeRequest func(ctx context.Context) error
+	// MaxBody membatasi body yang dibaca (0 = DefaultMaxBody).
+	MaxBody int64
+}
+
+// Response adalah respons yang sudah dibaca (body dipotong di MaxBody).
+type Response struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// Get mengirim GET ke url. header (opsional) dipasang setelah Prepare,
+// jadi header uji (mis. Origin) menimpa header dari Prepare. Client nil
+// memakai http.DefaultClient tanpa batas tambahan.
+func (c *Client) Get(ctx context.Context, url string, header http.Header) (*Response, error) {
+	if c == nil {
+		c = &Client{}
+	}
+	if c.BeforeRequest != nil {
+		if err := c.BeforeRequest(ctx); err != nil {
+			return nil, err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.Prepare != nil {
+		c.Prepare(req)
+	}
+	for k, vs := range header {
+		req.Header[http.CanonicalHeaderKey(k)] = vs
+	}
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	limit := c.MaxBody
+	if limit <= 0 {
+		limit = DefaultMaxBody
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit))
+	if err != nil {
+		return nil, err
+	}
+	return &Response{Status: resp.StatusCode, Header: resp.Header, Body: body}, nil
+}
@@ -0,0 +1,65 @@
+package probe
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Origin", r.Header.Get("Origin"))
+		w.Header().Set("X-Auth", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusTeapot)
+		w.Write([]byte(strings.Repeat("a", 100)))
+	}))
+	defer srv.Close()
+
+	calls := 0
+	c := &Client{
+		HTTP: srv.Client(),
+		Prepare: func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer x")
+			req.Header.Set("Origin", "https://prepare.example")
+		},
+		BeforeRequest: func(context.Context) error { calls++; return nil },
+		MaxBody:       10,
+	}
+	resp, err := c.Get(context.Background(), srv.URL, http.Header{"origin": {"https://evil.example"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != http.StatusTeapot {
+		t.Errorf("status = %d", resp.Status)
+	}
+	if got := resp.Header.Get("X-Origin"); got != "https://evil.example" {
+		t.Errorf("Origin = %q, header uji harus menimpa Prepare", got)
+	}
+	if got := resp.Header.Get("X-Auth"); got != "Bearer x" {
+		t.Errorf("Authorization = %q", got)
+	}
+	if len(resp.Body) != 10 {
+		t.Errorf("body %d byte, want 10 (MaxBody)", len(resp.Body))
+	}
+	if calls != 1 {
+		t.Errorf("BeforeRequest dipanggil %d kali", calls)
+	}
+}
+
+func TestGetBeforeRequestError(t *testing.T) {
+	hit := false
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { hit = true }))
+	defer srv.Close()
+
+	stop := errors.New("stop")
+	c := &Client{BeforeRequest: func(context.Context) error { return stop }}
+	if _, err := c.Get(context.Background(), srv.URL, nil); !errors.Is(err, stop) {
+		t.Fatalf("err = %v, want %v", err, stop)
+	}
+	if hit {
+		t.Error("request tetap dikirim setelah BeforeRequest gagal")
+	}
+}
@@ -0,0 +1,77 @@
+package report
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/D0Lv-1N/BUGx/internal/runner"
+)
+
+// File extensions per format, dipakai DefaultPath.
+const (
+	ExtHTML  = ".html"
+	ExtSARIF = ".sarif"
+	ExtJUnit = ".junit.xml"
+)
+
+// DefaultPath -> ~/BUGx/reports/<domain>/<run-id><ext>
+func DefaultPath(res runner.Result, ext string) string {
+	return filepath.Join(reportsDir(res), runName(res)+ext)
+}
+
+// DefaultMarkdownDir -> ~/BUGx/reports/<domain>/<run-id>-md
+func DefaultMarkdownDir(res runner.Result) string {
+	return filepath.Join(reportsDir(res), runName(res)+"-md")
+}
+
+func reportsDir(res runner.Result) string {
+	domain := res.Domain
+	if domain == "" {
+		domain = "target"
+	}
+	return filepath.Join(runner.BaseDir(), "reports", domain)
+}
+
+// runName: run ID, atau waktu mulai untuk Result tanpa ID.
+func runName(res runner.Result) string {
+	if res.ID != "" {
+		return res.ID
+	}
+	return res.Start.Format("20060102-150405")
+}
+
+// WriteHTMLFile writes the HTML report to path, creating parent directories.
+func WriteHTMLFile(path string, res runner.Result) error {
+	return writeFile(path, func(w io.Writer) error { return WriteHTML(w, res) })
+}
+
+// WriteSARIFFile writes the SARIF log to path ("-" = stdout).
+func WriteSARIFFile(path string, res runner.Result) error {
+	return writeFile(path, func(w io.Writer) error { return WriteSARIF(w, res) })
+}
+
+// WriteJUnitFile writes the JUnit XML to path ("-" = stdout).
+func WriteJUnitFile(path string, res runner.Result, threshold string) error {
+	return writeFile(path, func(w io.Writer) error { return WriteJUnit(w, res, threshold) })
+}
+
+// writeFile membuat folder induk lalu menulis lewat write. Path "-" menulis
+// ke stdout (berguna di pipeline CI).
+func writeFile(path string, write func(io.Writer) error) error {
+	if path == "-" {
+		return write(os.Stdout)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
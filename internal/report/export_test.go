@@ -0,0 +1,235 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+	"github.com/D0Lv-1N/BUGx/internal/runner"
+)
+
+func sampleRun() runner.Result {
+	start := time.Date(2026, 10, 16, 20, 25, 12, 0, time.UTC)
+	return runner.Result{
+		ID:     "20261016-202512-3fa2",
+		Domain: "example.com",
+		Start:  start,
+		End:    start.Add(10 * time.Minute),
+		Steps: []runner.StepRecord{
+			{Mode: "recon", Name: "subfinder", Tool: "subfinder", Status: runner.StepOK, Start: start, Duration: time.Minute},
+			{Mode: "xss", Name: "dalfox", Tool: "dalfox", Status: runner.StepOK, Duration: 2 * time.Minute},
+			{Mode: "xss", Name: "gf xss", Tool: "gf", Status: runner.StepMissingTool},
+			{Mode: "sqli", Name: "nuclei sqli", Tool: "nuclei", Status: runner.StepTimeout, Error: "timeout 30m"},
+		},
+		Findings: []findings.Finding{
+			{Tool: "nuclei", Mode: "sqli", ID: "error-based-sqli", Name: "Error based SQL injection", Severity: "critical", URL: "https://shop.example.com/item?id=1"},
+			{Tool: "dalfox", Mode: "xss", ID: "dalfox-verified", Name: "XSS (verified)", Severity: "high", URL: "https://www.example.com/?q=1", Param: "q"},
+			{Tool: "dalfox", Mode: "xss", ID: "dalfox-verified", Name: "XSS (verified)", Severity: "high", URL: "https://www.example.com/s?q=2", Param: "q"},
+			{Tool: "dalfox", Mode: "xss", ID: "dalfox-reflected", Name: "XSS (reflected)", Severity: "medium", URL: "https://www.example.com/r?x=1"},
+			{Tool: "nuclei", Mode: "sqli", ID: "tech-detect", Severity: "info", URL: "https://shop.example.com"},
+			{Tool: "nuclei", Mode: "sqli", ID: "weird", Severity: "bogus", URL: "https://shop.example.com/w"},
+		},
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, sampleRun()); err != nil {
+		t.Fatal(err)
+	}
+	var log struct {
+		Schema  string `json:"$schema"`
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID                   string `json:"id"`
+						DefaultConfiguration struct {
+							Level string `json:"level"`
+						} `json:"defaultConfiguration"`
+						Properties map[string]any `json:"properties"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				RuleIndex int    `json:"ruleIndex"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+				PartialFingerprints map[string]string `json:"partialFingerprints"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("SARIF bukan JSON valid: %v\n%s", err, buf.String())
+	}
+	if log.Schema != "https://json.schemastore.org/sarif-2.1.0.json" || log.Version != "2.1.0" {
+		t.Errorf("$schema/version = %q / %q", log.Schema, log.Version)
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("runs = %d, want 1", len(log.Runs))
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "BUGx" {
+		t.Errorf("driver = %q", run.Tool.Driver.Name)
+	}
+
+	// Satu rule per tool/template, urutan kemunculan pertama.
+	wantRules := []struct{ id, level string }{
+		{"nuclei/error-based-sqli", "error"},
+		{"dalfox/dalfox-verified", "error"},
+		{"dalfox/dalfox-reflected", "warning"},
+		{"nuclei/tech-detect", "note"},
+		{"nuclei/weird", "none"},
+	}
+	rules := run.Tool.Driver.Rules
+	if len(rules) != len(wantRules) {
+		t.Fatalf("rules = %d, want %d", len(rules), len(wantRules))
+	}
+	for i, w := range wantRules {
+		if rules[i].ID != w.id || rules[i].DefaultConfiguration.Level != w.level {
+			t.Errorf("rules[%d] = %s/%s, want %s/%s", i, rules[i].ID, rules[i].DefaultConfiguration.Level, w.id, w.level)
+		}
+	}
+	if got := rules[0].Properties["security-severity"]; got != "9.5" {
+		t.Errorf("security-severity critical = %v", got)
+	}
+
+	if len(run.Results) != 6 {
+		t.Fatalf("results = %d, want 6", len(run.Results))
+	}
+	seen := make(map[string]bool)
+	for i, r := range run.Results {
+		if rules[r.RuleIndex].ID != r.RuleID {
+			t.Errorf("results[%d]: ruleIndex %d menunjuk %s, ruleId %s", i, r.RuleIndex, rules[r.RuleIndex].ID, r.RuleID)
+		}
+		if r.Level != rules[r.RuleIndex].DefaultConfiguration.Level {
+			t.Errorf("results[%d].level = %s", i, r.Level)
+		}
+		if len(r.Locations) != 1 || r.Locations[0].PhysicalLocation.ArtifactLocation.URI == "" {
+			t.Errorf("results[%d] tanpa lokasi", i)
+		}
+		fp := r.PartialFingerprints["bugx/v1"]
+		if fp == "" || seen[fp] {
+			t.Errorf("results[%d] fingerprint %q kosong / duplikat", i, fp)
+		}
+		seen[fp] = true
+	}
+	if run.Results[2].RuleIndex != 1 {
+		t.Errorf("temuan kedua dalfox-verified harus memakai rule yang sama (index %d)", run.Results[2].RuleIndex)
+	}
+}
+
+func TestWriteSARIFEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, runner.Result{Domain: "example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	// Code scanning menolak "rules"/"results" null.
+	for _, want := range []string{`"rules": []`, `"results": []`} {
+		if !bytes.Contains(buf.Bytes(), []byte(want)) {
+			t.Errorf("SARIF kosong tidak memuat %s:\n%s", want, buf.String())
+		}
+	}
+}
+
+type junitDoc struct {
+	XMLName  xml.Name `xml:"testsuites"`
+	Tests    int      `xml:"tests,attr"`
+	Failures int      `xml:"failures,attr"`
+	Errors   int      `xml:"errors,attr"`
+	Skipped  int      `xml:"skipped,attr"`
+	Suites   []struct {
+		Name     string `xml:"name,attr"`
+		Tests    int    `xml:"tests,attr"`
+		Failures int    `xml:"failures,attr"`
+		Errors   int    `xml:"errors,attr"`
+		Skipped  int    `xml:"skipped,attr"`
+		Cases    []struct {
+			Name    string    `xml:"name,attr"`
+			Failure *struct{} `xml:"failure"`
+			Error   *struct{} `xml:"error"`
+			Skipped *struct{} `xml:"skipped"`
+		} `xml:"testcase"`
+		SystemOut string `xml:"system-out"`
+	} `xml:"testsuite"`
+}
+
+func TestWriteJUnit(t *testing.T) {
+	tests := []struct {
+		threshold string
+		failures  map[string]int // suite -> jumlah testcase gagal
+	}{
+		{"", map[string]int{}},
+		{findings.SevCritical, map[string]int{"bugx.sqli": 1}},
+		{findings.SevHigh, map[string]int{"bugx.sqli": 1, "bugx.xss": 2}},
+		{findings.SevMedium, map[string]int{"bugx.sqli": 1, "bugx.xss": 3}},
+		{findings.SevInfo, map[string]int{"bugx.sqli": 2, "bugx.xss": 3}},
+	}
+	for _, tt := range tests {
+		t.Run("fail-on="+tt.threshold, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteJUnit(&buf, sampleRun(), tt.threshold); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.HasPrefix(buf.Bytes(), []byte(xml.Header)) {
+				t.Error("tanpa header XML")
+			}
+			var doc junitDoc
+			if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+				t.Fatalf("JUnit bukan XML valid: %v", err)
+			}
+
+			// Satu suite per mode, urutan kemunculan; satu testcase per step.
+			wantSuites := []struct {
+				name  string
+				steps int
+			}{{"bugx.recon", 1}, {"bugx.xss", 2}, {"bugx.sqli", 1}}
+			if len(doc.Suites) != len(wantSuites) {
+				t.Fatalf("suites = %d, want %d", len(doc.Suites), len(wantSuites))
+			}
+			total := 0
+			for i, w := range wantSuites {
+				s := doc.Suites[i]
+				if s.Name != w.name {
+					t.Errorf("suites[%d] = %s, want %s", i, s.Name, w.name)
+				}
+				fails := 0
+				for _, c := range s.Cases {
+					if c.Failure != nil {
+						fails++
+					}
+				}
+				if fails != tt.failures[w.name] || s.Failures != fails {
+					t.Errorf("%s failures = %d (attr %d), want %d", s.Name, fails, s.Failures, tt.failures[w.name])
+				}
+				if len(s.Cases)-fails != w.steps || s.Tests != len(s.Cases) {
+					t.Errorf("%s testcase step = %d (tests %d), want %d", s.Name, len(s.Cases)-fails, s.Tests, w.steps)
+				}
+				total += s.Failures
+			}
+			if doc.Failures != total {
+				t.Errorf("testsuites failures = %d, want %d", doc.Failures, total)
+			}
+			// Step timeout -> error, tool hilang -> skipped; tidak pernah failure.
+			if doc.Errors != 1 || doc.Skipped != 1 {
+				t.Errorf("errors/skipped = %d/%d, want 1/1", doc.Errors, doc.Skipped)
+			}
+			// Semua temuan tetap tercantum di system-out, terlepas dari threshold.
+			if !bytes.Contains([]byte(doc.Suites[1].SystemOut), []byte("[medium] XSS (reflected) -> https://www.example.com/r?x=1")) {
+				t.Errorf("system-out xss:\n%s", doc.Suites[1].SystemOut)
+			}
+		})
+	}
+}
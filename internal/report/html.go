@@ -0,0 +1,133 @@
+package report
+
+import (
+	"embed"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/D0Lv-1N/BUGx/internal/diff"
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+	"github.com/D0Lv-1N/BUGx/internal/runner"
+)
+
+//go:embed templates/report.html.tmpl
+var templatesFS embed.FS
+
+var htmlTmpl = template.Must(template.New("report.html.tmpl").Funcs(template.FuncMap{
+	"join":     strings.Join,
+	"fmtTime":  func(t time.Time) string { return t.Format("2006-01-02 15:04:05 MST") },
+	"fmtClock": func(t time.Time) string { return t.Format("15:04:05") },
+	"fmtDur":   fmtDuration,
+	"assetRow": func(label string, a diff.Assets) assetRowData { return assetRowData{label, a} },
+}).ParseFS(templatesFS, "templates/report.html.tmpl"))
+
+// htmlData is the view model rendered by report.html.tmpl.
+type htmlData struct {
+	Run        runner.Result
+	Generated  time.Time
+	Duration   time.Duration
+	Severities []string
+	Counts     map[string]int
+	Matrix     []modeRow
+	Groups     []severityGroup
+	ShowNew    bool // ada run pembanding, tandai temuan baru
+}
+
+type assetRowData struct {
+	Label string
+	A     diff.Assets
+}
+
+type modeRow struct {
+	Mode   string
+	Counts []int // urutan sama dengan Severities
+	Total  int
+}
+
+type severityGroup struct {
+	Severity string
+	Findings []findings.Finding
+	ByMode   []modeGroup
+}
+
+type modeGroup struct {
+	Mode     string
+	Findings []findings.Finding
+}
+
+// WriteHTML renders a self-contained HTML report (CSS inline, tanpa aset luar).
+func WriteHTML(w io.Writer, res runner.Result) error {
+	return htmlTmpl.Execute(w, buildHTMLData(res))
+}
+
+func buildHTMLData(res runner.Result) htmlData {
+	d := htmlData{
+		Run:        res,
+		Generated:  time.Now(),
+		Duration:   res.End.Sub(res.Start),
+		Severities: findings.Severities,
+		Counts:     findings.CountBySeverity(res.Findings),
+		ShowNew:    res.Diff != nil && res.Diff.PrevID != "",
+	}
+
+	sorted := append([]findings.Finding(nil), res.Findings...)
+	findings.SortBySeverity(sorted)
+
+	perMode := make(map[string]map[string]int)
+	for _, f := range sorted {
+		if perMode[f.Mode] == nil {
+			perMode[f.Mode] = make(map[string]int)
+		}
+		perMode[f.Mode][findings.NormalizeSeverity(f.Severity)]++
+	}
+	modes := make([]string, 0, len(perMode))
+	for m := range perMode {
+		modes = append(modes, m)
+	}
+	sort.Strings(modes)
+	for _, m := range modes {
+		row := modeRow{Mode: m}
+		for _, sev := range findings.Severities {
+			n := perMode[m][sev]
+			row.Counts = append(row.Counts, n)
+			row.Total += n
+		}
+		d.Matrix = append(d.Matrix, row)
+	}
+
+	for _, sev := range findings.Severities {
+		g := severityGroup{Severity: sev}
+		for _, f := range sorted {
+			if findings.NormalizeSeverity(f.Severity) != sev {
+				continue
+			}
+			g.Findings = append(g.Findings, f)
+			if n := len(g.ByMode); n == 0 || g.ByMode[n-1].Mode != f.Mode {
+				g.ByMode = append(g.ByMode, modeGroup{Mode: f.Mode})
+			}
+			last := &g.ByMode[len(g.ByMode)-1]
+			last.Findings = append(last.Findings, f)
+		}
+		if len(g.Findings) > 0 {
+			d.Groups = append(d.Groups, g)
+		}
+	}
+	return d
+}
+
+// fmtDuration formats durations compactly (1h02m03s, 4.2s, 120ms).
+func fmtDuration(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return "-"
+	case d < time.Second:
+		return d.Round(time.Millisecond).String()
+	case d < time.Minute:
+		return d.Round(100 * time.Millisecond).String()
+	default:
+		return d.Round(time.Second).String()
+	}
+}
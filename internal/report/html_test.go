@@ -0,0 +1,109 @@
+package report
+
+import (
+	"bytes"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/D0Lv-1N/BUGx/internal/diff"
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+	"github.com/D0Lv-1N/BUGx/internal/runner"
+)
+
+func TestBuildHTMLData(t *testing.T) {
+	d := buildHTMLData(sampleRun())
+
+	if d.Duration != 10*time.Minute || d.ShowNew {
+		t.Errorf("Duration = %s, ShowNew = %v", d.Duration, d.ShowNew)
+	}
+	// Kolom matrix mengikuti findings.Severities (critical..unknown).
+	wantMatrix := []modeRow{
+		{Mode: "sqli", Counts: []int{1, 0, 0, 0, 1, 1}, Total: 3},
+		{Mode: "xss", Counts: []int{0, 2, 1, 0, 0, 0}, Total: 3},
+	}
+	if !reflect.DeepEqual(d.Matrix, wantMatrix) {
+		t.Errorf("Matrix = %+v, want %+v", d.Matrix, wantMatrix)
+	}
+
+	var sevs []string
+	for _, g := range d.Groups {
+		sevs = append(sevs, g.Severity)
+		n := 0
+		for _, m := range g.ByMode {
+			n += len(m.Findings)
+		}
+		if n != len(g.Findings) {
+			t.Errorf("%s: ByMode berisi %d temuan, Findings %d", g.Severity, n, len(g.Findings))
+		}
+	}
+	wantSevs := []string{findings.SevCritical, findings.SevHigh, findings.SevMedium, findings.SevInfo, findings.SevUnknown}
+	if !reflect.DeepEqual(sevs, wantSevs) {
+		t.Errorf("Groups = %v, want %v (severity kosong tidak ditampilkan)", sevs, wantSevs)
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	tests := []struct {
+		name    string
+		edit    func(r *runner.Result)
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "tanpa run pembanding",
+			want:    []string{"example.com", "20261016-202512-3fa2", "error-based-sqli", "https://shop.example.com/item?id=1", "dalfox-verified"},
+			notWant: []string{`class="new"`},
+		},
+		{
+			name: "temuan baru ditandai",
+			edit: func(r *runner.Result) {
+				r.Diff = &diff.Run{PrevID: "20261015-100000-aaaa", NewFindings: 1}
+				r.Findings[0].New = true
+			},
+			want: []string{`<span class="new">NEW</span> Error based SQL injection`, "20261015-100000-aaaa"},
+		},
+		{
+			name: "input di-escape",
+			edit: func(r *runner.Result) {
+				r.Findings[0].Name = `<script>alert(1)</script>`
+			},
+			want:    []string{"&lt;script&gt;alert(1)&lt;/script&gt;"},
+			notWant: []string{"<script>alert(1)"},
+		},
+	}
+	// Laporan harus self-contained: tanpa script / stylesheet / gambar luar.
+	external := regexp.MustCompile(`(?i)<(script|link|img)[^>]+(src|href)=["']?https?:`)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := sampleRun()
+			if tt.edit != nil {
+				tt.edit(&res)
+			}
+			var buf bytes.Buffer
+			if err := WriteHTML(&buf, res); err != nil {
+				t.Fatal(err)
+			}
+			html := buf.String()
+			if !strings.HasPrefix(strings.TrimSpace(html), "<!DOCTYPE html>") {
+				t.Errorf("output bukan dokumen HTML: %.40q", html)
+			}
+			if loc := external.FindString(html); loc != "" {
+				t.Errorf("aset luar di laporan: %s", loc)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(html, w) {
+					t.Errorf("laporan tidak berisi %q", w)
+				}
+			}
+			for _, w := range tt.notWant {
+				if strings.Contains(html, w) {
+					t.Errorf("laporan berisi %q", w)
+				}
+			}
+		})
+	}
+}
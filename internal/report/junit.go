@@ -0,0 +1,175 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+	"github.com/D0Lv-1N/BUGx/internal/runner"
+)
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Errors   int          `xml:"errors,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Errors    int         `xml:"errors,attr"`
+	Skipped   int         `xml:"skipped,attr"`
+	Time      string      `xml:"time,attr"`
+	Timestamp string      `xml:"timestamp,attr,omitempty"`
+	Cases     []junitCase `xml:"testcase"`
+	SystemOut *junitText  `xml:"system-out,omitempty"`
+
+	dur float64 // total durasi testcase (detik)
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	Skipped   *junitProblem `xml:"skipped,omitempty"`
+	SystemOut *junitText    `xml:"system-out,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Body    string `xml:",cdata"`
+}
+
+type junitText struct {
+	Text string `xml:",cdata"`
+}
+
+// WriteJUnit menulis hasil run sebagai JUnit XML: satu testsuite per mode,
+// satu testcase per step, dan satu testcase gagal per temuan dengan severity
+// >= threshold. Threshold kosong berarti tidak ada temuan yang dianggap gagal
+// (temuan tetap dicantumkan di system-out suite).
+func WriteJUnit(w io.Writer, res runner.Result, threshold string) error {
+	var order []string
+	suites := make(map[string]*junitSuite)
+	suite := func(mode string) *junitSuite {
+		if mode == "" {
+			mode = "misc"
+		}
+		s, ok := suites[mode]
+		if !ok {
+			s = &junitSuite{Name: "bugx." + mode}
+			suites[mode] = s
+			order = append(order, mode)
+		}
+		return s
+	}
+
+	for _, st := range res.Steps {
+		s := suite(st.Mode)
+		if s.Timestamp == "" && !st.Start.IsZero() {
+			s.Timestamp = st.Start.Format("2006-01-02T15:04:05")
+		}
+		tc := junitCase{
+			Name:      st.Name,
+			Classname: "bugx." + st.Mode + "." + st.Tool,
+			Time:      seconds(st.Duration.Seconds()),
+		}
+		if st.Command != "" || st.Log != "" {
+			tc.SystemOut = &junitText{Text: fmt.Sprintf("Command: %s\nExit code: %d\nLog: %s\n", st.Command, st.ExitCode, st.Log)}
+		}
+		s.dur += st.Duration.Seconds()
+		switch st.Status {
+		case runner.StepOK:
+		case runner.StepFailed, runner.StepTimeout, runner.StepAborted:
+			tc.Error = &junitProblem{Message: st.Error, Type: st.Status}
+		default:
+			tc.Skipped = &junitProblem{Message: st.Status}
+		}
+		s.Cases = append(s.Cases, tc)
+	}
+
+	for _, f := range res.Findings {
+		s := suite(f.Mode)
+		sev := findings.NormalizeSeverity(f.Severity)
+		line := fmt.Sprintf("[%s] %s -> %s", sev, firstNonEmpty(f.Name, f.ID), f.URL)
+		if s.SystemOut == nil {
+			s.SystemOut = &junitText{}
+		}
+		s.SystemOut.Text += line + "\n"
+		if threshold == "" || findings.Rank(sev) < findings.Rank(threshold) {
+			continue
+		}
+		var body strings.Builder
+		fmt.Fprintf(&body, "Tool: %s\nTemplate: %s\nSeverity: %s\nURL: %s\n", f.Tool, f.ID, sev, f.URL)
+		if f.Param != "" {
+			fmt.Fprintf(&body, "Param: %s\n", f.Param)
+		}
+		if f.Evidence != "" {
+			fmt.Fprintf(&body, "Evidence: %s\n", f.Evidence)
+		}
+		s.Cases = append(s.Cases, junitCase{
+			Name:      fmt.Sprintf("%s @ %s", firstNonEmpty(f.Name, f.ID), f.URL),
+			Classname: "bugx." + f.Mode + "." + f.Tool,
+			Time:      seconds(0),
+			Failure: &junitProblem{
+				Message: fmt.Sprintf("%s finding (threshold %s)", sev, threshold),
+				Type:    sev,
+				Body:    body.String(),
+			},
+		})
+	}
+
+	out := junitSuites{
+		Name: "BUGx " + res.Domain,
+		Time: seconds(res.End.Sub(res.Start).Seconds()),
+	}
+	for _, mode := range order {
+		s := suites[mode]
+		for _, tc := range s.Cases {
+			s.Tests++
+			switch {
+			case tc.Failure != nil:
+				s.Failures++
+			case tc.Error != nil:
+				s.Errors++
+			case tc.Skipped != nil:
+				s.Skipped++
+			}
+		}
+		s.Time = seconds(s.dur)
+		out.Tests += s.Tests
+		out.Failures += s.Failures
+		out.Errors += s.Errors
+		out.Skipped += s.Skipped
+		out.Suites = append(out.Suites, *s)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(s float64) string {
+	if s < 0 {
+		s = 0
+	}
+	return fmt.Sprintf("%.3f", s)
+}
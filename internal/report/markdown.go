@@ -0,0 +1,315 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+	"github.com/D0Lv-1N/BUGx/internal/runner"
+)
+
+// vulnClass adalah teks boilerplate satu kelas kerentanan untuk draft
+// laporan bug bounty (bahasa Inggris, siap tempel ke HackerOne/Bugcrowd).
+type vulnClass struct {
+	Name     string
+	Weakness string // CWE yang umum dipakai platform
+	Summary  string
+	Impact   string
+	Fix      string
+}
+
+// vulnClasses dikunci dengan nama mode bawaan.
+var vulnClasses = map[string]vulnClass{
+	"xss": {
+		Name:     "Cross-Site Scripting (XSS)",
+		Weakness: "CWE-79: Improper Neutralization of Input During Web Page Generation",
+		Summary:  "User-controlled input is reflected into the page without proper output encoding, allowing arbitrary JavaScript to execute in the victim's browser in the context of the affected origin.",
+		Impact:   "An attacker can craft a link that, once opened by a logged-in user, runs JavaScript on their behalf: stealing session tokens or CSRF tokens, performing actions as the victim, reading sensitive data shown on the page, or presenting a convincing phishing form on the trusted domain.",
+		Fix:      "Contextually encode all user input before rendering it (HTML, attribute, JavaScript and URL contexts), validate input against an allow-list, and deploy a restrictive Content-Security-Policy.",
+	},
+	"sqli": {
+		Name:     "SQL Injection",
+		Weakness: "CWE-89: Improper Neutralization of Special Elements used in an SQL Command",
+		Summary:  "A request parameter is concatenated into an SQL query, allowing an attacker to change the structure of the query.",
+		Impact:   "An attacker can read or modify data in the backing database, including credentials and personal data of other users, bypass authentication, and depending on the DBMS and privileges, write files or execute commands on the database server.",
+		Fix:      "Use parameterized queries / prepared statements for every database access, apply least-privilege database accounts, and validate input types server-side.",
+	},
+	"lfi": {
+		Name:     "Local File Inclusion / Path Traversal",
+		Weakness: "CWE-22: Improper Limitation of a Pathname to a Restricted Directory",
+		Summary:  "A parameter used to build a file path on the server is not restricted, allowing traversal outside the intended directory.",
+		Impact:   "An attacker can read arbitrary files readable by the web server process, such as configuration files, source code, credentials and keys. When the file is included/executed, this can escalate to remote code execution.",
+		Fix:      "Map user input to an allow-list of known files instead of using it as a path, canonicalize paths and reject anything outside the base directory, and disable remote includes.",
+	},
+	"ssrf": {
+		Name:     "Server-Side Request Forgery (SSRF)",
+		Weakness: "CWE-918: Server-Side Request Forgery",
+		Summary:  "The server fetches a URL that is controlled by the user without restricting the destination.",
+		Impact:   "An attacker can make the server send requests to internal services that are not reachable from the internet, including cloud metadata endpoints (e.g. 169.254.169.254) that can leak credentials, and can use the server to scan or attack the internal network.",
+		Fix:      "Validate destinations against an allow-list, resolve and block private, loopback and link-local address ranges (including after redirects), and require IMDSv2 or equivalent on cloud instances.",
+	},
+	"redirect": {
+		Name:     "Open Redirect",
+		Weakness: "CWE-601: URL Redirection to Untrusted Site",
+		Summary:  "The application redirects users to a URL taken from a request parameter without validating the destination.",
+		Impact:   "An attacker can use the trusted domain to send victims to a malicious site for phishing or malware delivery, and in OAuth/SSO flows the redirect can be chained to leak authorization codes or tokens.",
+		Fix:      "Only redirect to relative paths or to an allow-list of destinations, and show an interstitial warning for external redirects.",
+	},
+	"sensitive": {
+		Name:     "Sensitive Information Exposure",
+		Weakness: "CWE-200: Exposure of Sensitive Information to an Unauthorized Actor",
+		Summary:  "A file or endpoint that should not be public is accessible without authentication.",
+		Impact:   "Exposed backups, configuration files, logs or debug endpoints can disclose credentials, API keys, source code, internal hostnames or personal data, which an attacker can use to compromise the application or its infrastructure.",
+		Fix:      "Remove the exposed resource from the web root, restrict access with authentication or network rules, and rotate any secrets that were disclosed.",
+	},
+	"cms": {
+		Name:     "Vulnerable / Misconfigured CMS or Admin Panel",
+		Weakness: "CWE-1104: Use of Unmaintained Third Party Components",
+		Summary:  "The CMS, plugin or administrative panel in use is exposed and matches a known vulnerability or insecure configuration.",
+		Impact:   "Depending on the component, an attacker can enumerate users, brute-force or bypass the admin login, exploit known CVEs in the outdated component, and potentially take over the site.",
+		Fix:      "Update the CMS core and plugins to supported versions, restrict administrative interfaces to trusted networks, and remove unused components.",
+	},
+	"rce": {
+		Name:     "Remote Code Execution",
+		Weakness: "CWE-94: Improper Control of Generation of Code",
+		Summary:  "The target is affected by a vulnerability that allows execution of attacker-controlled code or commands on the server.",
+		Impact:   "An attacker can execute arbitrary commands with the privileges of the application, leading to full compromise of the server, access to all data it can reach, and a foothold for lateral movement into the internal network.",
+		Fix:      "Apply the vendor patch or upgrade the affected component immediately, remove the vulnerable endpoint if it is not needed, and review the host for signs of compromise.",
+	},
+	"takeover": {
+		Name:     "Subdomain Takeover",
+		Weakness: "CWE-284: Improper Access Control",
+		Summary:  "A subdomain has a DNS record (usually a CNAME) pointing to a third-party service resource that no longer exists and can be claimed by anyone.",
+		Impact:   "An attacker can register the dangling resource and serve arbitrary content on the trusted subdomain: phishing pages, malware, or JavaScript that reads cookies scoped to the parent domain, bypasses CORS/CSP allow-lists that trust the subdomain, or hijacks OAuth redirects.",
+		Fix:      "Remove the DNS record or reclaim the resource on the third-party service, and add a deprovisioning step that deletes DNS records before cloud resources are released.",
+	},
+	"cors": {
+		Name:     "CORS Misconfiguration",
+		Weakness: "CWE-942: Permissive Cross-domain Policy with Untrusted Domains",
+		Summary:  "The server reflects an untrusted Origin (an arbitrary domain, null, or a look-alike of the target domain) in Access-Control-Allow-Origin.",
+		Impact:   "When credentials are allowed, any website visited by a logged-in user can send authenticated requests to the affected endpoint and read the responses, leaking personal data, API keys or CSRF tokens and enabling actions on the victim's behalf.",
+		Fix:      "Validate the Origin header against an exact allow-list of trusted origins, never reflect it blindly or trust null, and only send Access-Control-Allow-Credentials: true for origins that need it.",
+	},
+	"js": {
+		Name:     "Hardcoded Secret in JavaScript",
+		Weakness: "CWE-798: Use of Hard-coded Credentials",
+		Summary:  "A publicly served JavaScript file contains a credential such as an API key, access token or private key.",
+		Impact:   "Anyone can download the file and use the credential to access the associated service (cloud accounts, messaging workspaces, payment or third-party APIs) with the privileges granted to it, potentially exposing data or incurring costs on the owner's account.",
+		Fix:      "Remove the secret from client-side code, rotate it immediately, move privileged calls to the backend, and restrict any key that must stay public (referrer / API restrictions).",
+	},
+	"crlf": {
+		Name:     "CRLF Injection / HTTP Response Splitting",
+		Weakness: "CWE-113: Improper Neutralization of CRLF Sequences in HTTP Headers",
+		Summary:  "Encoded carriage-return / line-feed characters in a request are written unfiltered into a response header, allowing new headers to be injected.",
+		Impact:   "An attacker can craft a link that sets arbitrary cookies (session fixation), injects security-relevant headers, or splits the response to inject HTML/JavaScript (XSS), and may poison shared caches.",
+		Fix:      "Reject or strip CR and LF characters from any user input placed in response headers, and use the framework's header APIs that enforce this.",
+	},
+	"ssti": {
+		Name:     "Server-Side Template Injection (SSTI)",
+		Weakness: "CWE-1336: Improper Neutralization of Special Elements Used in a Template Engine",
+		Summary:  "User input is embedded into a server-side template and evaluated by the template engine.",
+		Impact:   "An attacker can execute template expressions on the server, which in most engines leads to reading server-side data and configuration and to remote code execution with the privileges of the application.",
+		Fix:      "Never build templates from user input; pass input only as template data, use a sandboxed / logic-less engine where user templates are required, and apply input validation.",
+	},
+}
+
+// genericClass dipakai untuk mode custom / temuan yang kelasnya tidak dikenal.
+var genericClass = vulnClass{
+	Name:    "Security Misconfiguration",
+	Summary: "The automated scan identified the issue described below on the affected asset.",
+	Impact:  "Describe what an attacker can achieve with this issue, which users or data are affected, and any preconditions required.",
+	Fix:     "Describe the recommended fix.",
+}
+
+// classKeywords memetakan kata kunci template-id ke kelas. Dicek sebelum
+// mode, karena satu mode bisa menjalankan template dari kelas lain (mis.
+// mode rce memakai tags rce,critical,takeover).
+var classKeywords = []struct {
+	keyword string
+	class   string
+}{
+	{"xss", "xss"},
+	{"sqli", "sqli"},
+	{"sql-injection", "sqli"},
+	{"lfi", "lfi"},
+	{"traversal", "lfi"},
+	{"ssrf", "ssrf"},
+	{"redirect", "redirect"},
+	{"rce", "rce"},
+	{"exposure", "sensitive"},
+	{"exposures", "sensitive"},
+	{"backup", "sensitive"},
+	{"takeover", "takeover"},
+	{"cors", "cors"},
+	{"crlf", "crlf"},
+	{"ssti", "ssti"},
+}
+
+// broadModes menjalankan template campuran (tags critical, ...), jadi nama
+// mode saja tidak cukup untuk memilih boilerplate.
+var broadModes = map[string]bool{"rce": true}
+
+// classFor memilih boilerplate: kata kunci di ID temuan dulu, lalu mode.
+func classFor(f findings.Finding) vulnClass {
+	id := idTokens(f.ID)
+	for _, k := range classKeywords {
+		if hasTokens(id, idTokens(k.keyword)) {
+			return vulnClasses[k.class]
+		}
+	}
+	if c, ok := vulnClasses[f.Mode]; ok && !broadModes[f.Mode] {
+		return c
+	}
+	return genericClass
+}
+
+// idTokens memecah template-id di - dan _ ("CVE-2021_rce-x" -> cve 2021 rce x).
+func idTokens(id string) []string {
+	return strings.FieldsFunc(strings.ToLower(id), func(r rune) bool { return r == '-' || r == '_' })
+}
+
+// hasTokens: kw muncul sebagai token utuh berurutan di id, supaya "rce"
+// tidak cocok dengan "source" / "resource".
+func hasTokens(id, kw []string) bool {
+	for i := 0; i+len(kw) <= len(id); i++ {
+		match := len(kw) > 0
+		for j := range kw {
+			if id[i+j] != kw[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkdownDraft menyusun satu draft laporan bug bounty untuk satu temuan.
+func MarkdownDraft(f findings.Finding) string {
+	c := classFor(f)
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", draftTitle(f, c))
+
+	fmt.Fprintf(&b, "| | |\n|---|---|\n")
+	fmt.Fprintf(&b, "| **Severity** | %s |\n", capitalize(findings.NormalizeSeverity(f.Severity)))
+	fmt.Fprintf(&b, "| **Vulnerability** | %s |\n", c.Name)
+	if c.Weakness != "" {
+		fmt.Fprintf(&b, "| **Weakness** | %s |\n", c.Weakness)
+	}
+	fmt.Fprintf(&b, "| **Affected URL** | `%s` |\n", mdCell(f.URL))
+	if f.Param != "" {
+		fmt.Fprintf(&b, "| **Parameter / matcher** | `%s` |\n", mdCell(f.Param))
+	}
+	fmt.Fprintf(&b, "| **Detected by** | %s (`%s`) |\n", f.Tool, mdCell(f.ID))
+	if !f.Timestamp.IsZero() {
+		fmt.Fprintf(&b, "| **Detected at** | %s |\n", f.Timestamp.UTC().Format("2006-01-02 15:04 UTC"))
+	}
+
+	fmt.Fprintf(&b, "\n## Summary\n\n%s\n", c.Summary)
+	fmt.Fprintf(&b, "\nThe issue was identified at `%s`", f.URL)
+	if f.Param != "" {
+		fmt.Fprintf(&b, " (`%s`)", f.Param)
+	}
+	b.WriteString(".\n")
+
+	b.WriteString("\n## Steps to reproduce\n\n")
+	b.WriteString("1. Send the following request:\n\n")
+	fmt.Fprintf(&b, "```bash\n%s\n```\n\n", curlFor(f))
+	if f.Request != "" {
+		b.WriteString("Raw request:\n\n")
+		fmt.Fprintf(&b, "```http\n%s\n```\n\n", strings.TrimSpace(f.Request))
+	}
+	if f.Evidence != "" {
+		fmt.Fprintf(&b, "2. Observe the response. Evidence captured during the scan:\n\n```\n%s\n```\n", f.Evidence)
+	} else {
+		b.WriteString("2. Observe the response confirming the issue.\n")
+	}
+
+	fmt.Fprintf(&b, "\n## Impact\n\n%s\n", c.Impact)
+	fmt.Fprintf(&b, "\n## Remediation\n\n%s\n", c.Fix)
+	b.WriteString("\n---\n_Draft generated by BUGx. Verify the finding manually and add screenshots before submitting._\n")
+	return b.String()
+}
+
+func draftTitle(f findings.Finding, c vulnClass) string {
+	host := runner.DomainOf(f.URL)
+	name := f.Name
+	if name == "" || name == f.ID {
+		name = c.Name
+	}
+	if host == "" {
+		return name
+	}
+	return name + " on " + host
+}
+
+// curlFor: pakai curl dari tool bila ada, jika tidak buat GET sederhana.
+func curlFor(f findings.Finding) string {
+	if f.Curl != "" {
+		return strings.TrimSpace(f.Curl)
+	}
+	return "curl -i -s -k " + shellQuote(f.URL)
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// mdCell membuat teks aman untuk sel tabel / inline code Markdown.
+func mdCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "`", "'")
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+var slugRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+func slug(s string) string {
+	s = strings.Trim(slugRe.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if len(s) > 50 {
+		s = strings.TrimRight(s[:50], "-")
+	}
+	if s == "" {
+		s = "finding"
+	}
+	return s
+}
+
+// WriteMarkdownDrafts menulis satu file .md per temuan ke dir dan
+// mengembalikan path yang ditulis (urutan severity).
+// Nama file: NNN-<severity>-<mode>-<id>.md
+func WriteMarkdownDrafts(dir string, list []findings.Finding) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	sorted := append([]findings.Finding(nil), list...)
+	findings.SortBySeverity(sorted)
+
+	var paths []string
+	for i, f := range sorted {
+		mode := f.Mode
+		if mode == "" {
+			mode = f.Tool
+		}
+		name := fmt.Sprintf("%03d-%s-%s-%s.md", i+1, findings.NormalizeSeverity(f.Severity), slug(mode), slug(f.ID))
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(MarkdownDraft(f)), 0o644); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
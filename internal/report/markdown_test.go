@@ -0,0 +1,49 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+)
+
+func TestClassFor(t *testing.T) {
+	tests := []struct {
+		mode, id string
+		want     string
+	}{
+		{"custom", "CVE-2021-1234-rce", "Remote Code Execution"},
+		{"custom", "apache_rce_cgi", "Remote Code Execution"},
+		{"custom", "source-map-disclosure", genericClass.Name},
+		{"custom", "aws-resource-listing", genericClass.Name},
+		{"custom", "generic-sql-injection", "SQL Injection"},
+		{"custom", "git-config-exposures", "Sensitive Information Exposure"},
+		{"custom", "cors-misconfig", "CORS Misconfiguration"},
+		{"takeover", "takeover-github-pages", "Subdomain Takeover"},
+		{"cors", "cors-null-origin", "CORS Misconfiguration"},
+		{"js", "js-aws-access-key", "Hardcoded Secret in JavaScript"},
+		{"crlf", "crlf-set-cookie", "CRLF Injection / HTTP Response Splitting"},
+		{"ssti", "ssti-jinja2", "Server-Side Template Injection (SSTI)"},
+		{"xss", "dalfox-verified", "Cross-Site Scripting (XSS)"},
+		{"rce", "CVE-2021-26084-rce", "Remote Code Execution"},
+		{"rce", "azure-takeover-detection", "Subdomain Takeover"},
+		{"rce", "aws-bucket-takeover", "Subdomain Takeover"},
+		{"rce", "springboot-env-exposure", "Sensitive Information Exposure"},
+		{"rce", "CVE-2022-22947", genericClass.Name},
+		{"sqli", "tech-detect", "SQL Injection"},
+	}
+	for _, tt := range tests {
+		got := classFor(findings.Finding{Mode: tt.mode, ID: tt.id})
+		if got.Name != tt.want {
+			t.Errorf("classFor(%s, %s) = %q, want %q", tt.mode, tt.id, got.Name, tt.want)
+		}
+	}
+}
+
+func TestNewModesHaveImpact(t *testing.T) {
+	for _, mode := range []string{"takeover", "cors", "js", "crlf", "ssti"} {
+		c, ok := vulnClasses[mode]
+		if !ok || c.Impact == "" || c.Impact == genericClass.Impact {
+			t.Errorf("mode %s tidak punya boilerplate Impact", mode)
+		}
+	}
+}
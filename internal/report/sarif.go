@@ -0,0 +1,196 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+	"github.com/D0Lv-1N/BUGx/internal/runner"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	bugxInfoURI  = "https://github.com/D0Lv-1N/BUGx"
+)
+
+// Subset SARIF 2.1.0 yang dibutuhkan code-scanning (GitHub, GitLab, dst).
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string         `json:"id"`
+	Name                 string         `json:"name,omitempty"`
+	ShortDescription     sarifMessage   `json:"shortDescription"`
+	DefaultConfiguration sarifConfig    `json:"defaultConfiguration"`
+	Properties           map[string]any `json:"properties,omitempty"`
+}
+
+type sarifConfig struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Properties          map[string]any    `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysical `json:"physicalLocation"`
+}
+
+type sarifPhysical struct {
+	ArtifactLocation sarifArtifact `json:"artifactLocation"`
+}
+
+type sarifArtifact struct {
+	URI string `json:"uri"`
+}
+
+// sarifLevel memetakan severity BUGx ke level SARIF.
+func sarifLevel(sev string) string {
+	switch findings.NormalizeSeverity(sev) {
+	case findings.SevCritical, findings.SevHigh:
+		return "error"
+	case findings.SevMedium:
+		return "warning"
+	case findings.SevLow, findings.SevInfo:
+		return "note"
+	default:
+		return "none"
+	}
+}
+
+// securitySeverity adalah skor 0-10 yang dipakai GitHub code scanning untuk
+// mengelompokkan alert (critical >= 9.0, high >= 7.0, medium >= 4.0).
+func securitySeverity(sev string) string {
+	switch findings.NormalizeSeverity(sev) {
+	case findings.SevCritical:
+		return "9.5"
+	case findings.SevHigh:
+		return "8.0"
+	case findings.SevMedium:
+		return "5.5"
+	case findings.SevLow:
+		return "3.0"
+	default:
+		return "0.0"
+	}
+}
+
+// WriteSARIF menulis semua temuan run sebagai satu run SARIF 2.1.0. Setiap
+// kombinasi tool/template menjadi satu rule; URL temuan menjadi lokasi.
+func WriteSARIF(w io.Writer, res runner.Result) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "BUGx",
+			InformationURI: bugxInfoURI,
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	ruleIndex := make(map[string]int)
+	for _, f := range res.Findings {
+		id := f.Tool + "/" + f.ID
+		idx, ok := ruleIndex[id]
+		if !ok {
+			idx = len(run.Tool.Driver.Rules)
+			ruleIndex[id] = idx
+			c := classFor(f)
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+				ID:                   id,
+				Name:                 f.Name,
+				ShortDescription:     sarifMessage{Text: firstNonEmpty(f.Name, c.Name, f.ID)},
+				DefaultConfiguration: sarifConfig{Level: sarifLevel(f.Severity)},
+				Properties: map[string]any{
+					"tags":              nonEmpty("security", f.Mode, f.Tool),
+					"security-severity": securitySeverity(f.Severity),
+				},
+			})
+		}
+
+		msg := firstNonEmpty(f.Name, f.ID) + " (" + findings.NormalizeSeverity(f.Severity) + ") at " + f.URL
+		if f.Evidence != "" {
+			msg += ": " + f.Evidence
+		}
+		props := map[string]any{
+			"severity": findings.NormalizeSeverity(f.Severity),
+			"mode":     f.Mode,
+			"tool":     f.Tool,
+		}
+		if f.Param != "" {
+			props["param"] = f.Param
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    id,
+			RuleIndex: idx,
+			Level:     sarifLevel(f.Severity),
+			Message:   sarifMessage{Text: msg},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysical{
+				ArtifactLocation: sarifArtifact{URI: f.URL},
+			}}},
+			PartialFingerprints: map[string]string{"bugx/v1": fingerprint(f)},
+			Properties:          props,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}})
+}
+
+// fingerprint mengidentifikasi temuan yang sama antar run (tanpa timestamp),
+// supaya dashboard bisa mendeteksi alert baru / yang sudah hilang.
+func fingerprint(f findings.Finding) string {
+	sum := sha256.Sum256([]byte(f.Key()))
+	return hex.EncodeToString(sum[:16])
+}
+
+func nonEmpty(vals ...string) []string {
+	out := make([]string, 0, len(vals))
+	for _, v := range vals {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
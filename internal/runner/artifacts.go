@@ -0,0 +1,131 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// manifestFile adalah nama manifest artefak di setiap folder results mode.
+const manifestFile = "manifest.json"
+
+// Artifact adalah satu file output step yang disimpan di folder results run
+// (Options.KeepArtifacts).
+type Artifact struct {
+	Mode  string `json:"mode"`
+	Step  string `json:"step"`
+	Tool  string `json:"tool"`
+	File  string `json:"file"`
+	Lines int    `json:"lines"`
+	Bytes int64  `json:"bytes"`
+}
+
+// keepArtifact mencatat output step sebagai artefak. File di work dir
+// (gf_*, clean_*, ...) disalin ke folder results mode; output yang sudah di
+// folder results (subs/hosts/gau, nuclei.json) hanya dicatat.
+func keepArtifact(env *chainEnv, st *Step, out string) (Artifact, bool) {
+	if !env.Opts.KeepArtifacts || out == "" || !fileExists(out) {
+		return Artifact{}, false
+	}
+	dir := env.ResultsDir
+	if dir == "" && env.Recon != nil {
+		dir = env.Recon.Corpus
+	}
+	if dir == "" {
+		return Artifact{}, false
+	}
+
+	dst := out
+	if filepath.Dir(out) != dir {
+		dst = filepath.Join(dir, filepath.Base(out))
+		if err := copyFile(out, dst); err != nil {
+			logFail(env.Chain.Label, "simpan artefak "+filepath.Base(out), err)
+			return Artifact{}, false
+		}
+	}
+	a := Artifact{Mode: env.Chain.Name, Step: st.Name, Tool: st.Tool, File: dst}
+	a.Lines, a.Bytes = countLines(dst)
+	return a, true
+}
+
+// writeManifests menulis manifest.json (artefak + step penghasil + jumlah
+// baris) di setiap folder results yang punya artefak.
+func writeManifests(arts []Artifact) {
+	byDir := make(map[string][]Artifact)
+	var dirs []string
+	for _, a := range arts {
+		d := filepath.Dir(a.File)
+		if _, ok := byDir[d]; !ok {
+			dirs = append(dirs, d)
+		}
+		byDir[d] = append(byDir[d], a)
+	}
+	for _, d := range dirs {
+		// Di manifest path relatif terhadap foldernya sendiri.
+		list := byDir[d]
+		for i := range list {
+			list[i].File = filepath.Base(list[i].File)
+		}
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		err := enc.Encode(list)
+		if err == nil {
+			err = os.WriteFile(filepath.Join(d, manifestFile), buf.Bytes(), 0o644)
+		}
+		if err != nil {
+			fmt.Printf("[ARTIFACT] [WARN] Manifest %s tidak bisa ditulis: %v\n", d, err)
+		}
+	}
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// countLines returns the number of lines (baris terakhir tanpa newline tetap
+// dihitung) and the file size.
+func countLines(path string) (int, int64) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0
+	}
+	defer f.Close()
+
+	buf := make([]byte, 32*1024)
+	lines := 0
+	var size int64
+	var last byte
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			lines += bytes.Count(buf[:n], []byte{'\n'})
+			size += int64(n)
+			last = buf[n-1]
+		}
+		if err != nil {
+			break
+		}
+	}
+	if size > 0 && last != '\n' {
+		lines++
+	}
+	return lines, size
+}
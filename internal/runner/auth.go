@@ -0,0 +1,177 @@
+package runner
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AuthDir -> ~/BUGx/auth (header auth default per domain, <domain>.txt).
+func AuthDir() string {
+	return filepath.Join(buildBugxBaseDir(), "auth")
+}
+
+// ParseHeader memvalidasi satu header "Nama: nilai".
+func ParseHeader(raw string) (string, error) {
+	name, value, ok := strings.Cut(strings.TrimSpace(raw), ":")
+	name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+	if !ok || name == "" || value == "" || strings.ContainsAny(name, " \t") {
+		return "", fmt.Errorf("header tidak valid: %q (format: \"Nama: nilai\")", redactHeader(raw))
+	}
+	return name + ": " + value, nil
+}
+
+// CookieHeader mengubah "sid=abc; theme=dark" menjadi header Cookie.
+func CookieHeader(raw string) string {
+	return "Cookie: " + strings.TrimSpace(raw)
+}
+
+// LoadHeaders membaca file header auth: satu "Nama: nilai" per baris,
+// # untuk komentar. Baris tanpa ":" yang berisi "=" dianggap cookie.
+func LoadHeaders(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []string
+	sc := bufio.NewScanner(f)
+	n := 0
+	for sc.Scan() {
+		n++
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !strings.Contains(line, ":") && strings.Contains(line, "=") {
+			out = append(out, CookieHeader(line))
+			continue
+		}
+		h, err := ParseHeader(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s baris %d: %w", filepath.Base(path), n, err)
+		}
+		out = append(out, h)
+	}
+	return out, sc.Err()
+}
+
+// setupAuth memakai ~/BUGx/auth/<domain>.txt bila header tidak diisi lewat
+// menu / CLI. Hanya nama header yang ditampilkan.
+func setupAuth(opts *Options, domain string) {
+	if len(opts.Headers) == 0 {
+		path := filepath.Join(AuthDir(), sanitizeForPath(domain)+".txt")
+		if !fileExists(path) {
+			return
+		}
+		hs, err := LoadHeaders(path)
+		if err != nil {
+			fmt.Printf("[AUTH] [WARN] Header default tidak bisa dibaca: %v\n", err)
+			return
+		}
+		opts.Headers = hs
+		fmt.Printf("[AUTH] Memakai %s\n", path)
+	}
+	if len(opts.Headers) > 0 {
+		fmt.Printf("[AUTH] Header dikirim ke semua tool: %s\n", strings.Join(headerNames(opts.Headers), ", "))
+	}
+}
+
+// headerNames returns only the header names (aman untuk log / history).
+func headerNames(headers []string) []string {
+	var out []string
+	for _, h := range headers {
+		name, _, _ := strings.Cut(h, ":")
+		out = append(out, strings.TrimSpace(name))
+	}
+	return unique(out)
+}
+
+// authFlags menerjemahkan header auth ke flag masing-masing tool. Tool baru
+// cukup ditambahkan di sini; flag ditambahkan di belakang Args.
+var authFlags = map[string]func(headers []string) []string{
+	"httpx":  headerFlags("-H"),
+	"nuclei": headerFlags("-H"),
+	"katana": headerFlags("-H"),
+	"ffuf":   headerFlags("-H"),
+	// dalfox: cookie lewat -C, header lain lewat -H.
+	"dalfox": func(headers []string) []string {
+		var args []string
+		for _, h := range headers {
+			name, value, _ := strings.Cut(h, ":")
+			if strings.EqualFold(strings.TrimSpace(name), "cookie") {
+				args = append(args, "-C", strings.TrimSpace(value))
+				continue
+			}
+			args = append(args, "-H", h)
+		}
+		return args
+	},
+}
+
+func headerFlags(flag string) func(headers []string) []string {
+	return func(headers []string) []string {
+		var args []string
+		for _, h := range headers {
+			args = append(args, flag, h)
+		}
+		return args
+	}
+}
+
+// withAuthFlags menambahkan header auth ke args tool yang dikenal.
+func withAuthFlags(tool string, args []string, o *Options) []string {
+	fn, ok := authFlags[tool]
+	if !ok || len(o.Headers) == 0 {
+		return args
+	}
+	return append(args, fn(o.Headers)...)
+}
+
+// secretFlags: argumen setelah flag ini berisi header / cookie dan disensor
+// di log ([CMD], RUN ->).
+var secretFlags = map[string]bool{
+	"-H": true, "-header": true, "--header": true, "--headers": true,
+	"-C": true, "-cookie": true, "--cookie": true, "-b": true,
+}
+
+// redactArgs returns a copy of args with header / cookie values and URL
+// credentials masked.
+func redactArgs(args []string) []string {
+	out := make([]string, len(args))
+	for i, a := range args {
+		if i > 0 && secretFlags[args[i-1]] {
+			if strings.Contains(a, ":") {
+				a = redactHeader(a)
+			} else {
+				a = "***"
+			}
+		}
+		out[i] = redactURLs(a)
+	}
+	return out
+}
+
+// redactHeader: "Authorization: Bearer abc" -> "Authorization: ***".
+func redactHeader(h string) string {
+	name, _, ok := strings.Cut(h, ":")
+	if !ok {
+		return "***"
+	}
+	return name + ": ***"
+}
+
+// redactSecrets mengganti nilai header auth di teks (request / curl temuan)
+// supaya token tidak ikut tersimpan di history dan laporan.
+func redactSecrets(text string, headers []string) string {
+	for _, h := range headers {
+		_, value, _ := strings.Cut(h, ":")
+		if value = strings.TrimSpace(value); len(value) >= 4 {
+			text = strings.ReplaceAll(text, value, "***")
+		}
+	}
+	return text
+}
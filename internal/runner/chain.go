@@ -0,0 +1,427 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+)
+
+// Step adalah satu tahap deklaratif di dalam Chain.
+//
+// Template (Args, Shell, Inputs, Output) boleh memakai placeholder:
+//
+//	{domain}   domain target
+//	{name}     nama chain (xss, sqli, ...)
+//	{speed}    kecepatan (>= 1)
+//	{work}     work dir run (file antara, ~/BUGx/work/<run-id>)
+//	{results}  folder hasil mode per run (~/BUGx/results/<mode>/<domain>/<run-id>)
+//	{base}     base dir BUGx (~/BUGx)
+//	{subs} {hosts} {urls}  corpus recon bersama
+//	{in}       input pertama yang ada dari Inputs
+//	{out}      hasil expand Output
+//
+// Pada Shell, setiap nilai placeholder di-escape untuk sh -c. Output step
+// TargetList difilter scope (Options.Scope) sebelum step berikutnya
+// berjalan. Step Args untuk tool yang dikenal (lihat rateFlags)
+// otomatis mendapat flag rate limit, header auth dan proxy dari Options;
+// step Shell mendapat flag proxy tool-nya plus HTTP_PROXY/HTTPS_PROXY.
+type Step struct {
+	Name   string     // label di log, mis. "httpx (gf_xss->clean)"
+	Tool   string     // binary yang wajib ada di PATH (dicatat di Tools Used)
+	Args   []string   // template argumen untuk exec langsung
+	Shell  string     // alternatif Args: template pipeline sh -c
+	Run    nativeFunc // alternatif Args/Shell: cek native tanpa binary eksternal
+	Inputs []string   // kandidat input; yang pertama ada menjadi {in}
+	Output string     // template path output -> {out}
+
+	// TargetList menandai Output sebagai list target (subs, hosts, URL; satu
+	// entri per baris) yang difilter scope. Hanya step recon, gf, httpx dan
+	// crawler; output tool lain (JSON, laporan) tidak boleh ditulis ulang.
+	TargetList bool
+
+	// When (opsional) menentukan apakah step relevan untuk run ini.
+	// Step yang tidak relevan dilewati tanpa log.
+	When func(env *chainEnv) bool
+}
+
+// Chain adalah definisi satu mode: identitas menu/CLI + urutan Step.
+type Chain struct {
+	ID        int      // nomor menu
+	Name      string   // nama CLI & folder results (xss, sqli, ...)
+	Aliases   []string // nama CLI alternatif (rfi, backup, ...)
+	Menu      string   // teks di menu utama, mis. "LFI / RFI"
+	Label     string   // prefix log, mis. "XSS"
+	Title     string   // judul banner, mis. "MODE XSS"
+	NeedsURLs bool     // butuh corpus gau dari recon
+	Steps     []Step
+}
+
+// chainEnv adalah state yang dibagi semua step dalam satu eksekusi chain.
+type chainEnv struct {
+	Chain      *Chain
+	Recon      *reconResult
+	Opts       *Options
+	Intr       *interrupter
+	Checkpoint *checkpoint // step selesai (resume); nil = tanpa checkpoint
+	ResultsDir string
+	NeedURLs   bool // hanya relevan untuk chain recon
+}
+
+// Step status values recorded in StepRecord.
+const (
+	StepOK          = "ok"
+	StepFailed      = "failed"
+	StepTimeout     = "timeout"
+	StepSkipped     = "skipped"  // dilewati user (Ctrl-C)
+	StepAborted     = "aborted"  // run dihentikan saat step berjalan
+	StepMissingTool = "missing"  // binary tidak ada di PATH
+	StepNoInput     = "no-input" // file input belum ada
+)
+
+// StepRecord mencatat eksekusi satu step untuk ringkasan dan laporan.
+type StepRecord struct {
+	Mode     string        `json:"mode"`
+	Name     string        `json:"name"`
+	Tool     string        `json:"tool"`
+	Status   string        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	ExitCode int           `json:"exit_code"`         // -1 bila proses tidak berjalan / dibunuh
+	Dropped  int           `json:"dropped,omitempty"` // entri output yang dibuang filter scope
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+	Command  string        `json:"command,omitempty"` // command line persis (nilai rahasia disensor)
+	Log      string        `json:"log,omitempty"`     // file log stdout/stderr step
+}
+
+// chainOutcome adalah hasil satu eksekusi chain.
+type chainOutcome struct {
+	Tools     []string           // tool yang benar-benar berhasil dijalankan
+	Findings  []findings.Finding // temuan dari output step yang punya parser
+	Steps     []StepRecord
+	Artifacts []Artifact // output step yang disimpan (Options.KeepArtifacts)
+}
+
+// runChain mengeksekusi semua step chain. Output step yang tool-nya punya
+// parser (nuclei, dalfox) dibaca menjadi Finding dengan Mode = nama chain.
+// Bila Options.Workers > 1, step yang tidak saling bergantung (lihat
+// stepDeps) berjalan bersamaan; hasil tetap disusun sesuai urutan step.
+// Bila ctx dibatalkan (run dihentikan user), step sisanya tidak dijalankan.
+func runChain(ctx context.Context, c *Chain, env *chainEnv) chainOutcome {
+	fmt.Printf("========== [%s] ==========\n", c.Title)
+
+	if env.ResultsDir != "" {
+		_ = os.MkdirAll(env.ResultsDir, 0o755)
+	}
+
+	var res chainOutcome
+	if env.Opts.pool.size() > 1 {
+		res = runChainParallel(ctx, c, env)
+	} else {
+		for i := range c.Steps {
+			if ctx.Err() != nil {
+				logInfo(c.Label, "Run dihentikan, step sisa dilewati.")
+				break
+			}
+			res.add(c, env, execStep(ctx, &c.Steps[i], env))
+		}
+	}
+
+	fmt.Printf("========== [/%s] =========\n", c.Title)
+	res.Tools = unique(res.Tools)
+	return res
+}
+
+// runChainParallel menjalankan setiap step di goroutine sendiri: step
+// menunggu step yang ia butuhkan selesai, lalu menunggu slot worker pool
+// (di dalam runStep). Catatan step digabung sesuai urutan chain.
+func runChainParallel(ctx context.Context, c *Chain, env *chainEnv) chainOutcome {
+	deps := stepDeps(c)
+	done := make([]chan struct{}, len(c.Steps))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	results := make([]stepResult, len(c.Steps))
+	var stopped sync.Once
+	var wg sync.WaitGroup
+	for i := range c.Steps {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer close(done[i])
+			for _, d := range deps[i] {
+				<-done[d]
+			}
+			if ctx.Err() != nil {
+				stopped.Do(func() { logInfo(c.Label, "Run dihentikan, step sisa dilewati.") })
+				return
+			}
+			results[i] = execStep(ctx, &c.Steps[i], env)
+		}(i)
+	}
+	wg.Wait()
+
+	var res chainOutcome
+	for _, r := range results {
+		res.add(c, env, r)
+	}
+	return res
+}
+
+// stepResult adalah hasil execStep untuk satu step.
+type stepResult struct {
+	Step   *Step
+	Record StepRecord
+	Output string
+}
+
+// execStep menjalankan satu step, atau memakai hasil checkpoint bila step
+// sudah selesai di run yang dilanjutkan.
+func execStep(ctx context.Context, st *Step, env *chainEnv) stepResult {
+	rec, out, resumed := resumedStep(env, st)
+	if !resumed {
+		rec, out = runStep(ctx, st, env)
+		env.Checkpoint.record(rec, out)
+	}
+	return stepResult{Step: st, Record: rec, Output: out}
+}
+
+// add menggabungkan hasil satu step ke outcome chain.
+func (res *chainOutcome) add(c *Chain, env *chainEnv, r stepResult) {
+	if r.Record.Status == "" {
+		return
+	}
+	res.Steps = append(res.Steps, r.Record)
+	// Temuan yang sudah tertulis sebelum step timeout / dilewati / gagal
+	// tetap dihitung (ringkasan, history, --fail-on).
+	res.Findings = append(res.Findings, collectFindings(c, r.Step, r.Output, env.Opts.Headers)...)
+	if r.Record.Status != StepOK {
+		return
+	}
+	res.Tools = append(res.Tools, r.Step.Tool)
+	if a, ok := keepArtifact(env, r.Step, r.Output); ok {
+		res.Artifacts = append(res.Artifacts, a)
+	}
+}
+
+// resumedStep mengembalikan catatan step yang sudah selesai di checkpoint
+// (run yang dilanjutkan), sehingga tidak dijalankan ulang.
+func resumedStep(env *chainEnv, st *Step) (StepRecord, string, bool) {
+	if st.When != nil && !st.When(env) {
+		return StepRecord{}, "", false
+	}
+	done, ok := env.Checkpoint.done(env.Chain.Name, st.Name)
+	if !ok {
+		return StepRecord{}, "", false
+	}
+	logInfo(env.Chain.Label, fmt.Sprintf("[RESUME] %s sudah selesai sebelumnya, dilewati.", st.Name))
+	return done.Record, done.Output, true
+}
+
+// collectFindings membaca output step menjadi Finding bila tool-nya dikenal.
+// Nilai header auth di request / curl disensor.
+func collectFindings(c *Chain, st *Step, out string, headers []string) []findings.Finding {
+	parser := st.Tool
+	if st.Run != nil {
+		parser = findings.ToolNative
+	}
+	if out == "" || findings.ParserFor(parser) == nil {
+		return nil
+	}
+	list, err := findings.ParseFile(parser, out)
+	if err != nil {
+		logFail(c.Label, "parse "+st.Tool, err)
+		return nil
+	}
+	for i := range list {
+		list[i].Mode = c.Name
+		list[i].Request = redactSecrets(list[i].Request, headers)
+		list[i].Curl = redactSecrets(list[i].Curl, headers)
+	}
+	return list
+}
+
+// runStep menjalankan satu step di bawah context turunan ctx dengan timeout
+// per tool. Output tool tetap tampil live dan di-tee ke
+// <results>/logs/<step>.log (lihat stepLog). Return catatan step (Status
+// kosong bila step tidak relevan) dan path output; path juga dikembalikan
+// untuk step yang timeout / dilewati / gagal karena output parsialnya masih
+// berisi temuan.
+func runStep(ctx context.Context, st *Step, env *chainEnv) (StepRecord, string) {
+	label := env.Chain.Label
+	rec := StepRecord{
+		Mode:     env.Chain.Name,
+		Name:     st.Name,
+		Tool:     st.Tool,
+		ExitCode: -1,
+		Start:    time.Now(),
+	}
+
+	if st.When != nil && !st.When(env) {
+		return StepRecord{}, ""
+	}
+	if st.Run == nil && !hasTool(st.Tool) {
+		logMissing(label, st.Tool)
+		rec.Status = StepMissingTool
+		return rec, ""
+	}
+
+	in := ""
+	if len(st.Inputs) > 0 {
+		var candidates []string
+		for _, p := range st.Inputs {
+			candidates = append(candidates, env.expand(p, "", "", false))
+		}
+		in = chooseFirstExisting(candidates...)
+		if in == "" {
+			logInfo(label, fmt.Sprintf("Input %s tidak ada, lewati %s", describeInputs(candidates), st.Name))
+			rec.Status = StepNoInput
+			return rec, ""
+		}
+	}
+	out := env.expand(st.Output, in, "", false)
+
+	// Tunggu slot worker; waktu antre tidak dihitung sebagai durasi step.
+	if !env.Opts.pool.acquire(ctx) {
+		rec.Status = StepAborted
+		rec.Error = ctx.Err().Error()
+		return rec, ""
+	}
+	defer env.Opts.pool.release()
+	rec.Start = time.Now()
+
+	var stepCtx context.Context
+	var cancel context.CancelFunc
+	timeout := env.Opts.timeoutFor(st.Tool)
+	if timeout > 0 {
+		stepCtx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		stepCtx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	var line string
+	var args []string
+	switch {
+	case st.Run != nil:
+		logNative(label, st.Name, in)
+		rec.Command = fmt.Sprintf("bugx %s %s -> %s (native)", st.Tool, in, out)
+	case st.Shell != "":
+		line = injectShellFlags(env.expand(st.Shell, in, out, true), st.Tool, env.Opts)
+		logShell(label, line)
+		rec.Command = redactURLs(line)
+	default:
+		args = make([]string, 0, len(st.Args))
+		for _, a := range st.Args {
+			args = append(args, env.expand(a, in, out, false))
+		}
+		args = withRateFlags(st.Tool, args, env.Opts)
+		args = withAuthFlags(st.Tool, args, env.Opts)
+		args = withProxyFlags(st.Tool, args, env.Opts)
+		logStep(label, st.Name, args)
+		rec.Command = strings.Join(append([]string{st.Tool}, redactArgs(args)...), " ")
+	}
+
+	slog := openStepLog(env, st, &rec)
+	defer slog.finish(&rec)
+	stdout, stderr, flush := stepOutput(env, slog)
+	intrID := env.Intr.begin(label+" "+st.Name, cancel)
+	var err error
+	switch {
+	case st.Run != nil:
+		fmt.Fprintf(stdout, "[NATIVE] %s\n", rec.Command)
+		err = st.Run(stepCtx, env, in, out, stdout)
+	case st.Shell != "":
+		err = runShellLive(stepCtx, stdout, stderr, line, proxyEnv(env.Opts))
+	default:
+		err = runCommandLive(stepCtx, stdout, stderr, st.Tool, args...)
+	}
+	flush()
+	skipped := env.Intr.end(intrID)
+	rec.Duration = time.Since(rec.Start)
+	rec.ExitCode = exitCodeOf(err)
+	// Output parsial step yang timeout / gagal / dilewati tetap bisa dipakai
+	// step berikutnya (chooseFirstExisting), jadi selalu difilter scope.
+	rec.Dropped = applyScope(env, st, out)
+
+	switch {
+	case err == nil:
+		rec.Status = StepOK
+		return rec, out
+	case errors.Is(err, context.DeadlineExceeded):
+		err = fmt.Errorf("timeout setelah %s", timeout)
+		rec.Status = StepTimeout
+		logFail(label, st.Name, err)
+	case skipped:
+		rec.Status = StepSkipped
+		logInfo(label, fmt.Sprintf("%s dilewati oleh user (Ctrl-C).", st.Name))
+	case ctx.Err() != nil:
+		rec.Status = StepAborted
+		logInfo(label, fmt.Sprintf("%s dihentikan (run dibatalkan).", st.Name))
+	default:
+		rec.Status = StepFailed
+		logFail(label, st.Name, err)
+	}
+	rec.Error = err.Error()
+	return rec, out
+}
+
+// exitCodeOf returns the process exit code for err (0 = sukses, -1 = proses
+// tidak selesai normal, mis. dibunuh karena timeout).
+func exitCodeOf(err error) int {
+	if err == nil {
+		return 0
+	}
+	var ee *exec.ExitError
+	if errors.As(err, &ee) {
+		return ee.ExitCode()
+	}
+	return -1
+}
+
+// expand mengganti placeholder template dengan nilai run ini.
+func (e *chainEnv) expand(tmpl, in, out string, shell bool) string {
+	if tmpl == "" || !strings.Contains(tmpl, "{") {
+		return tmpl
+	}
+
+	val := func(s string) string {
+		if shell {
+			return escapeShell(s)
+		}
+		return s
+	}
+
+	rc := e.Recon
+	pairs := []string{
+		"{domain}", val(rc.Domain),
+		"{name}", e.Chain.Name,
+		"{speed}", strconv.Itoa(maxInt(e.Opts.Speed, 1)),
+		"{work}", val(rc.Dir),
+		"{results}", val(e.ResultsDir),
+		"{base}", val(buildBugxBaseDir()),
+		"{subs}", val(rc.Subs),
+		"{hosts}", val(rc.Hosts),
+		"{urls}", val(rc.URLs),
+		"{in}", val(in),
+		"{out}", val(out),
+	}
+	return strings.NewReplacer(pairs...).Replace(tmpl)
+}
+
+// describeInputs meringkas kandidat input untuk pesan log (nama file saja).
+func describeInputs(paths []string) string {
+	names := make([]string, 0, len(paths))
+	for _, p := range paths {
+		names = append(names, filepath.Base(p))
+	}
+	return strings.Join(names, "/")
+}
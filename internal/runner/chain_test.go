@@ -0,0 +1,165 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+)
+
+// TestTimedOutStepKeepsFindings: temuan yang sudah ditulis nuclei sebelum
+// timeout tetap masuk ke hasil chain (dan --fail-on).
+func TestTimedOutStepKeepsFindings(t *testing.T) {
+	bin := t.TempDir()
+	fake := "#!/bin/sh\n" +
+		`echo '{"template-id":"error-based-sqli","info":{"name":"SQLi","severity":"critical"},"matched-at":"https://a.example.com/?id=1"}' > "$2"` + "\n" +
+		"exec sleep 10\n"
+	if err := os.WriteFile(filepath.Join(bin, "nuclei"), []byte(fake), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	work := t.TempDir()
+	c := &Chain{Name: "sqli", Label: "SQLi", Title: "SQLi", Steps: []Step{
+		{Name: "nuclei sqli", Tool: "nuclei", Args: []string{"-o", "{out}"}, Output: "{work}/nuclei.json"},
+	}}
+	opts := &Options{ToolTimeouts: map[string]time.Duration{"nuclei": 300 * time.Millisecond}}
+	env := &chainEnv{
+		Chain:      c,
+		Recon:      &reconResult{Domain: "example.com", Dir: work, Corpus: work},
+		Opts:       opts,
+		ResultsDir: filepath.Join(work, "results"),
+	}
+
+	start := time.Now()
+	out := runChain(context.Background(), c, env)
+	if time.Since(start) > 5*time.Second {
+		t.Fatal("step tidak dihentikan oleh timeout")
+	}
+	if len(out.Steps) != 1 || out.Steps[0].Status != StepTimeout {
+		t.Fatalf("steps = %+v, want satu step timeout", out.Steps)
+	}
+	if len(out.Findings) != 1 {
+		t.Fatalf("findings = %+v, want 1 temuan dari output parsial", out.Findings)
+	}
+	f := out.Findings[0]
+	if f.Mode != "sqli" || f.ID != "error-based-sqli" || f.Severity != findings.SevCritical {
+		t.Errorf("finding = %+v", f)
+	}
+	if len(out.Tools) != 0 {
+		t.Errorf("tools = %v; step timeout tidak dihitung sebagai tool yang berhasil", out.Tools)
+	}
+}
+
+// fakeTools menaruh script sh bernama tool di PATH test.
+func fakeTools(t *testing.T, tools map[string]string) {
+	t.Helper()
+	bin := t.TempDir()
+	for name, body := range tools {
+		if err := os.WriteFile(filepath.Join(bin, name), []byte("#!/bin/sh\n"+body+"\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestChainEnvExpand(t *testing.T) {
+	env := &chainEnv{
+		Chain: &Chain{Name: "xss"},
+		Recon: &reconResult{
+			Domain: "example.com",
+			Dir:    "/tmp/work dir",
+			Subs:   "/c/subs.txt",
+			Hosts:  "/c/hosts.txt",
+			URLs:   "/c/it's.txt",
+		},
+		Opts:       &Options{Speed: 4},
+		ResultsDir: "/r/xss",
+	}
+	tests := []struct {
+		tmpl  string
+		shell bool
+		want  string
+	}{
+		{"", false, ""},
+		{"-silent", false, "-silent"},
+		{"{domain}", false, "example.com"},
+		{"{work}/gf_xss.txt", false, "/tmp/work dir/gf_xss.txt"},
+		{"{results}/{name}-{speed}.json", false, "/r/xss/xss-4.json"},
+		{"{subs} {hosts} {urls}", false, "/c/subs.txt /c/hosts.txt /c/it's.txt"},
+		{"{in}>{out}", false, "/i.txt>/o.txt"},
+		{"{unknown}", false, "{unknown}"},
+		// Shell: nilai di-escape, {name} dan {speed} tidak perlu.
+		{"cat {urls} > {work}/x", true, `cat '/c/it'\''s.txt' > '/tmp/work dir'/x`},
+		{"{name} -t {speed}", true, "xss -t 4"},
+	}
+	for _, tt := range tests {
+		if got := env.expand(tt.tmpl, "/i.txt", "/o.txt", tt.shell); got != tt.want {
+			t.Errorf("expand(%q, shell=%v) = %q, want %q", tt.tmpl, tt.shell, got, tt.want)
+		}
+	}
+
+	// Speed 0 dianggap 1.
+	env.Opts.Speed = 0
+	if got := env.expand("{speed}", "", "", false); got != "1" {
+		t.Errorf("{speed} dengan Speed 0 = %q, want 1", got)
+	}
+}
+
+// TestRunChainInputs: {in} adalah kandidat Inputs pertama yang ada, {out}
+// dari Output, step tanpa input / tool dicatat tanpa dijalankan, dan When
+// yang false melewati step tanpa record.
+func TestRunChainInputs(t *testing.T) {
+	fakeTools(t, map[string]string{"echoargs": `echo "$@" > "$2"`})
+	work := t.TempDir()
+	if err := os.WriteFile(filepath.Join(work, "clean.txt"), []byte("https://a.example.com/?q=1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Chain{Name: "xss", Label: "XSS", Title: "XSS", Steps: []Step{
+		{Name: "fallback", Tool: "echoargs", Args: []string{"-o", "{out}", "-l", "{in}"},
+			Inputs: []string{"{work}/gf_xss.txt", "{work}/clean.txt"}, Output: "{work}/a.txt"},
+		{Name: "tanpa input", Tool: "echoargs", Args: []string{"-o", "{out}"},
+			Inputs: []string{"{work}/tidak-ada.txt"}, Output: "{work}/b.txt"},
+		{Name: "tool hilang", Tool: "bugx-tool-tidak-ada", Args: []string{"{out}"}, Output: "{work}/c.txt"},
+		{Name: "when false", Tool: "echoargs", Args: []string{"-o", "{out}"}, Output: "{work}/d.txt",
+			When: func(*chainEnv) bool { return false }},
+	}}
+	env := &chainEnv{
+		Chain: c,
+		Recon: &reconResult{Domain: "example.com", Dir: work, Corpus: work},
+		Opts:  &Options{},
+	}
+	out := runChain(context.Background(), c, env)
+
+	wantStatus := []string{StepOK, StepNoInput, StepMissingTool}
+	if len(out.Steps) != len(wantStatus) {
+		t.Fatalf("steps = %+v, want %d record", out.Steps, len(wantStatus))
+	}
+	for i, st := range out.Steps {
+		if st.Status != wantStatus[i] {
+			t.Errorf("step %q status = %s, want %s", st.Name, st.Status, wantStatus[i])
+		}
+	}
+	if len(out.Tools) != 1 || out.Tools[0] != "echoargs" {
+		t.Errorf("tools = %v", out.Tools)
+	}
+
+	got, err := os.ReadFile(filepath.Join(work, "a.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "-o " + filepath.Join(work, "a.txt") + " -l " + filepath.Join(work, "clean.txt")
+	if strings.TrimSpace(string(got)) != want {
+		t.Errorf("args = %q, want %q", got, want)
+	}
+	for _, f := range []string{"b.txt", "c.txt", "d.txt"} {
+		if fileExists(filepath.Join(work, f)) {
+			t.Errorf("%s dibuat oleh step yang seharusnya tidak jalan", f)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package runner
+
+// Definisi chain bawaan. Setiap mode hanya mendeskripsikan apa yang berbeda
+// (pola gf, tag nuclei, tool tambahan); urutan step bersama dibangun oleh
+// paramChain / hostChain sehingga perubahan stage cukup di satu tempat.
+
+// reconChain: subfinder -> httpx -> gau, dijalankan sekali per target.
+var reconChain = Chain{
+	Name:  "recon",
+	Label: "RECON",
+	Title: "RECON",
+	Steps: []Step{
+		{
+			Name:       "subfinder",
+			Tool:       "subfinder",
+			Args:       []string{"-d", "{domain}", "-o", "{out}", "-t", "{speed}"},
+			Output:     "{subs}",
+			TargetList: true,
+		},
+		{
+			Name:       "httpx (subs->hosts)",
+			Tool:       "httpx",
+			Args:       []string{"-l", "{in}", "-o", "{out}", "-mc", "200", "-t", "{speed}"},
+			Inputs:     []string{"{subs}"},
+			Output:     "{hosts}",
+			TargetList: true,
+		},
+		{
+			Name:       "gau",
+			Tool:       "gau",
+			Shell:      "cat {in} | gau --threads {speed} --verbose > {out}",
+			Inputs:     []string{"{hosts}"},
+			Output:     "{urls}",
+			TargetList: true,
+			When:       func(env *chainEnv) bool { return env.NeedURLs },
+		},
+	},
+}
+
+// builtinChains adalah mode bawaan yang tampil di menu (1..8, lalu 50+;
+// 9 = RUN ALL, 10..49 untuk mode custom).
+var builtinChains = []Chain{
+	// XSS (1): nuclei severity medium+ dan dalfox dengan payload custom.
+	paramChain(ModeXSS, "xss", "XSS", "MODE XSS", "xss",
+		nucleiStep(paramInputs, "--severity", "medium,high,critical", "-tags", "xss"),
+		Step{
+			Name: "dalfox",
+			Tool: "dalfox",
+			Args: []string{
+				"file", "{in}",
+				"--skip-mining-all",
+				"--custom-payload", "{base}/wordlist/xss.txt",
+				"-w", "{speed}",
+				"--format", "json",
+				"-o", "{out}",
+			},
+			Inputs: paramInputs,
+			Output: "{results}/dalfox.json",
+		},
+	).withMenu("XSS"),
+
+	// SQLi (2): untuk sqlmap kita hanya siapkan input list; tidak auto-exploit
+	// agresif (bisa ditambahkan sebagai opsi manual di masa depan).
+	paramChain(ModeSQLi, "sqli", "SQLi", "MODE SQLi", "sqli",
+		nucleiStep(paramInputs, "-tags", "sqli"),
+	).withMenu("SQLi"),
+
+	paramChain(ModeLFI, "lfi", "LFI", "MODE LFI/RFI", "lfi",
+		nucleiStep(paramInputs, "-tags", "lfi"),
+	).withAliases("rfi").withMenu("LFI / RFI"),
+
+	paramChain(ModeSSRF, "ssrf", "SSRF", "MODE SSRF", "ssrf",
+		nucleiStep(paramInputs, "-tags", "ssrf"),
+	).withMenu("SSRF"),
+
+	paramChain(ModeRedirect, "redirect", "REDIRECT", "MODE OPEN REDIRECT", "redirect",
+		nucleiStep(paramInputs, "-tags", "redirect"),
+	).withMenu("Open Redirect"),
+
+	// SENSITIVE (6): tidak memaksakan wordlist tertentu; user bebas pakai
+	// ffuf/dirsearch/gobuster/feroxbuster manual.
+	hostChain(ModeSensitive, "sensitive", "SENSITIVE", "MODE SENSITIVE/BACKUP",
+		nucleiStep(hostInputs, "-tags", "exposure,exposures,files,backup"),
+	).withAliases("backup").withMenu("Sensitive Files / Backup"),
+
+	// CMS (7): output -td berisi anotasi [tech], jadi disimpan terpisah dan
+	// tidak dipakai sebagai input nuclei. wpscan/whatweb tetap manual.
+	hostChain(ModeCMS, "cms", "CMS", "MODE CMS/PANEL",
+		Step{
+			Name:       "httpx (hosts tech-detect)",
+			Tool:       "httpx",
+			Args:       []string{"-l", "{in}", "-o", "{out}", "-td", "-t", "{speed}"},
+			Inputs:     []string{"{hosts}"},
+			Output:     "{results}/tech.txt",
+			TargetList: true,
+		},
+		nucleiStep(hostInputs, "-tags", "wp,wordpress,drupal,joomla,cms,login,panel"),
+	).withAliases("panel").withMenu("CMS / Panel"),
+
+	// RCE (8): nuclei critical/rce/takeover templates.
+	hostChain(ModeRCE, "rce", "RCE", "MODE RCE/HIGH IMPACT",
+		nucleiStep(hostInputs, "-tags", "rce,critical,takeover"),
+	).withMenu("RCE / High Impact"),
+
+	// TAKEOVER (50): cek CNAME native untuk semua subs (termasuk yang tidak
+	// hidup di httpx, justru kandidat dangling) + halaman error layanan.
+	hostChain(ModeTakeover, "takeover", "TAKEOVER", "MODE SUBDOMAIN TAKEOVER",
+		takeoverStep(),
+	).withAliases("cname").withMenu("Subdomain Takeover"),
+
+	// CORS (51): Origin uji (sembarang, null, bypass prefix/suffix,
+	// subdomain) ke host hidup + endpoint API dari corpus gau.
+	hostChain(ModeCORS, "cors", "CORS", "MODE CORS MISCONFIG",
+		corsStep(),
+	).withURLs().withMenu("CORS Misconfiguration"),
+
+	// JS (52): file .js dari crawl katana + corpus gau, diunduh dan diekstrak
+	// endpoint / secret-nya; hasil per host di {results}/js/.
+	hostChain(ModeJS, "js", "JS", "MODE JS HARVEST",
+		jsCrawlStep(),
+		jsStep(),
+	).withURLs().withAliases("javascript", "secrets").withMenu("JavaScript Secrets / Endpoints"),
+
+	// CRLF (53): parameter redirect (paling sering dipantulkan ke header
+	// Location) dibuat unik dengan qsreplace, lalu payload CRLF ter-encode di
+	// query dan path host.
+	paramChain(ModeCRLF, "crlf", "CRLF", "MODE CRLF INJECTION", "redirect",
+		qsreplaceStep("crlf"),
+		crlfStep(),
+	).withAliases("header-injection").withMenu("CRLF / Header Injection"),
+
+	// SSTI (54): polyglot + probe per engine (Jinja2, Twig, Freemarker,
+	// Velocity, ERB, Go template) ke parameter kandidat gf ssti.
+	paramChain(ModeSSTI, "ssti", "SSTI", "MODE SSTI", "ssti",
+		qsreplaceStep("ssti"),
+		sstiStep(),
+	).withAliases("template").withMenu("SSTI"),
+}
+
+// paramInputs: nuclei/dalfox diarahkan ke corpus gau atau hosts sebagai
+// fallback, bukan ke hasil gf agar cakupan tetap luas.
+var paramInputs = []string{"{urls}", "{hosts}"}
+
+// hostInputs: mode berbasis host memakai hosts hidup, fallback ke subs.
+var hostInputs = []string{"{hosts}", "{subs}"}
+
+// paramChain membangun mode berbasis parameter URL:
+// gau corpus -> gf <pattern> -> httpx (clean) -> steps tambahan.
+func paramChain(id int, name, label, title, gfPattern string, extra ...Step) Chain {
+	gfOut := "{work}/gf_" + name + ".txt"
+	steps := []Step{
+		{
+			Name:       "gf " + gfPattern,
+			Tool:       "gf",
+			Shell:      "cat {in} | gf " + gfPattern + " > {out}",
+			Inputs:     []string{"{urls}"},
+			Output:     gfOut,
+			TargetList: true,
+		},
+		{
+			Name:       "httpx (gf_" + name + "->clean)",
+			Tool:       "httpx",
+			Args:       []string{"-l", "{in}", "-o", "{out}", "-mc", "200", "-t", "{speed}"},
+			Inputs:     []string{gfOut},
+			Output:     "{work}/clean_" + name + ".txt",
+			TargetList: true,
+		},
+	}
+	return Chain{
+		ID:        id,
+		Name:      name,
+		Label:     label,
+		Title:     title,
+		NeedsURLs: true,
+		Steps:     append(steps, extra...),
+	}
+}
+
+// hostChain membangun mode berbasis host (tanpa gau/gf).
+func hostChain(id int, name, label, title string, steps ...Step) Chain {
+	return Chain{
+		ID:    id,
+		Name:  name,
+		Label: label,
+		Title: title,
+		Steps: steps,
+	}
+}
+
+// nucleiStep: nuclei -l <input> <extra...> -jsonl -o results/nuclei.json -c speed.
+// Output JSONL dibaca kembali oleh findings.ParseNuclei.
+func nucleiStep(inputs []string, extra ...string) Step {
+	args := append([]string{"-l", "{in}"}, extra...)
+	args = append(args, "-jsonl", "-o", "{out}", "-c", "{speed}")
+	return Step{
+		Name:   "nuclei",
+		Tool:   "nuclei",
+		Args:   args,
+		Inputs: inputs,
+		Output: "{results}/nuclei.json",
+	}
+}
+
+// qsMarker adalah nilai pengganti semua parameter oleh qsreplace.
+const qsMarker = "bugx"
+
+// qsreplaceStep: qsreplace menyeragamkan nilai parameter kandidat
+// clean_<name> sehingga URL yang hanya beda nilai (?next=/a vs ?next=/b)
+// cukup diuji sekali oleh step native berikutnya.
+func qsreplaceStep(name string) Step {
+	return Step{
+		Name:   "qsreplace (clean_" + name + "->unik)",
+		Tool:   "qsreplace",
+		Shell:  "cat {in} | qsreplace " + qsMarker + " > {out}",
+		Inputs: []string{"{work}/clean_" + name + ".txt"},
+		Output: "{work}/qs_" + name + ".txt",
+	}
+}
+
+// withMenu mengisi teks yang tampil di menu utama.
+func (c Chain) withMenu(menu string) Chain {
+	c.Menu = menu
+	return c
+}
+
+// withURLs menandai mode yang butuh corpus gau dari recon.
+func (c Chain) withURLs() Chain {
+	c.NeedsURLs = true
+	return c
+}
+
+// withAliases menambahkan nama CLI alternatif.
+func (c Chain) withAliases(aliases ...string) Chain {
+	c.Aliases = append(c.Aliases, aliases...)
+	return c
+}
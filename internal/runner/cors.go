@@ -0,0 +1,129 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/D0Lv-1N/BUGx/internal/cors"
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+)
+
+// corsMaxAPIURLs membatasi URL API dari corpus gau yang ikut diuji (selain
+// semua host hidup), supaya mode tetap cepat pada corpus besar.
+const corsMaxAPIURLs = 300
+
+// apiURLRe mengenali URL / path yang kemungkinan endpoint API (respons JSON
+// dengan data user, tempat CORS paling berdampak); dipakai juga mode JS.
+var apiURLRe = regexp.MustCompile(`(?i)(/api[/.?]|/api$|/v[0-9]+/|/graphql|/rest/|/oauth|/auth/|/users?[/?]|/account|/me[/?]|/profile|\.json(\?|$))`)
+
+// corsStep: kirim Origin uji ke host hidup + URL API gau.
+func corsStep() Step {
+	return Step{
+		Name:   "cors (origin probes)",
+		Tool:   "cors",
+		Run:    runCORS,
+		Inputs: []string{"{hosts}", "{subs}"},
+		Output: "{results}/cors.json",
+	}
+}
+
+// runCORS adalah nativeFunc mode CORS.
+func runCORS(ctx context.Context, env *chainEnv, in, out string, w io.Writer) error {
+	targets, err := readURLs(in)
+	if err != nil {
+		return err
+	}
+	apis := 0
+	if fileExists(env.Recon.URLs) {
+		api, err := apiURLs(env.Recon.URLs, corsMaxAPIURLs)
+		if err != nil {
+			return err
+		}
+		apis = len(api)
+		targets = append(targets, api...)
+	}
+
+	sw := &syncWriter{w: w}
+	lim := newLimiter(env.Opts.effectiveRate())
+	defer lim.stop()
+	chk := &cors.Checker{Client: nativeProbe(env.Opts, lim)}
+	sw.printf("[CORS] %d URL (%d endpoint API dari gau), origin penyerang %s\n", len(targets), apis, cors.AttackerDomain)
+
+	root := env.Recon.Domain
+	return scanTargets(ctx, env, targets, out, sw, func(ctx context.Context, target string) ([]findings.Finding, error) {
+		issues, err := chk.Check(ctx, target, root)
+		var list []findings.Finding
+		for _, is := range issues {
+			list = append(list, corsFinding(target, is))
+		}
+		return list, err
+	})
+}
+
+// apiURLs mengambil URL mirip API dari corpus gau, unik per host+path.
+func apiURLs(path string, limit int) ([]string, error) {
+	all, err := readURLs(path)
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	seen := make(map[string]bool)
+	for _, raw := range all {
+		if !apiURLRe.MatchString(raw) {
+			continue
+		}
+		u, err := url.Parse(raw)
+		if err != nil || u.Host == "" {
+			continue
+		}
+		key := u.Host + u.Path
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, raw)
+		if len(out) >= limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func corsFinding(target string, is cors.Issue) findings.Finding {
+	req := fmt.Sprintf("GET %s HTTP/1.1\r\nHost: %s\r\nOrigin: %s\r\n", requestURI(target), hostOf(target), is.Probe.Origin)
+	return findings.Finding{
+		Tool:     "cors",
+		ID:       "cors-" + is.Probe.Kind,
+		Name:     is.Title,
+		Severity: is.Severity,
+		URL:      target,
+		Param:    "Origin",
+		Evidence: fmt.Sprintf("Origin: %s -> Access-Control-Allow-Origin: %s, Access-Control-Allow-Credentials: %t",
+			is.Probe.Origin, is.AllowOrigin, is.Credentials),
+		Request:   req,
+		Curl:      fmt.Sprintf("curl -sk -i -H %s %s", escapeShell("Origin: "+is.Probe.Origin), escapeShell(target)),
+		Timestamp: time.Now(),
+	}
+}
+
+// requestURI -> path + query URL ("/" bila kosong).
+func requestURI(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "/"
+	}
+	return u.RequestURI()
+}
+
+// hostOf -> host[:port] URL.
+func hostOf(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
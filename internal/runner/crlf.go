@@ -0,0 +1,103 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/D0Lv-1N/BUGx/internal/crlf"
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+)
+
+// crlfMaxURLs membatasi kandidat URL berparameter yang diuji per run.
+const crlfMaxURLs = 500
+
+// crlfStep: payload CRLF ke parameter kandidat + path setiap host hidup.
+// Tanpa qsreplace dipakai hasil httpx langsung; tanpa kandidat sama sekali
+// hanya path host yang diuji.
+func crlfStep() Step {
+	return Step{
+		Name:   "crlf (header injection)",
+		Tool:   "crlf",
+		Run:    runCRLF,
+		Inputs: []string{"{work}/qs_crlf.txt", "{work}/clean_crlf.txt", "{hosts}"},
+		Output: "{results}/crlf.json",
+	}
+}
+
+// runCRLF adalah nativeFunc mode CRLF.
+func runCRLF(ctx context.Context, env *chainEnv, in, out string, w io.Writer) error {
+	var candidates []string
+	if in != env.Recon.Hosts {
+		all, err := readURLs(in)
+		if err != nil {
+			return err
+		}
+		candidates = all[:minInt(len(all), crlfMaxURLs)]
+	}
+	origins, err := crlfOrigins(env.Recon.Hosts, candidates)
+	if err != nil {
+		return err
+	}
+	targets := append(candidates, origins...)
+
+	sw := &syncWriter{w: w}
+	lim := newLimiter(env.Opts.effectiveRate())
+	defer lim.stop()
+	chk := &crlf.Checker{Client: nativeProbe(env.Opts, lim)}
+	sw.printf("[CRLF] %d URL berparameter + %d path host, %d payload\n", len(candidates), len(origins), len(crlf.Payloads))
+
+	return scanTargets(ctx, env, targets, out, sw, func(ctx context.Context, target string) ([]findings.Finding, error) {
+		issues, err := chk.Check(ctx, target)
+		var list []findings.Finding
+		for _, is := range issues {
+			list = append(list, crlfFinding(is))
+		}
+		return list, err
+	})
+}
+
+// crlfOrigins returns skema+host unik dari hosts.txt (bila ada) dan kandidat,
+// untuk injeksi di path.
+func crlfOrigins(hostsFile string, candidates []string) ([]string, error) {
+	var all []string
+	if fileExists(hostsFile) {
+		hosts, err := readURLs(hostsFile)
+		if err != nil {
+			return nil, err
+		}
+		all = hosts
+	}
+	var out []string
+	seen := make(map[string]bool)
+	for _, raw := range append(all, candidates...) {
+		u, err := url.Parse(raw)
+		if err != nil || u.Host == "" {
+			continue
+		}
+		origin := u.Scheme + "://" + u.Host
+		if !seen[origin] {
+			seen[origin] = true
+			out = append(out, origin)
+		}
+	}
+	return out, nil
+}
+
+func crlfFinding(is crlf.Issue) findings.Finding {
+	u := is.Probe.URL
+	return findings.Finding{
+		Tool:      "crlf",
+		ID:        "crlf-" + is.Kind,
+		Name:      is.Title,
+		Severity:  is.Severity,
+		URL:       u,
+		Param:     is.Probe.Param,
+		Evidence:  fmt.Sprintf("%s muncul di respons (payload %s di %s)", is.Header, is.Probe.Payload.Name, is.Probe.Param),
+		Request:   fmt.Sprintf("GET %s HTTP/1.1\r\nHost: %s\r\n", requestURI(u), hostOf(u)),
+		Curl:      fmt.Sprintf("curl -sk -i %s", escapeShell(u)),
+		Timestamp: time.Now(),
+	}
+}
@@ -0,0 +1,461 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// customModeSpec adalah format file mode buatan user di ~/BUGx/modes/
+// (*.yaml, *.yml, *.json). Contoh YAML:
+//
+//	name: graphql
+//	id: 12                   # opsional: nomor menu tetap (10-49)
+//	title: GraphQL
+//	gf: graphql              # opsional: aktifkan gau -> gf -> httpx
+//	nuclei:
+//	  tags: [graphql]
+//	  templates: [~/nuclei-templates/custom/graphql/]
+//	  severity: medium,high,critical
+//	  input: urls            # urls | hosts | subs | gf | clean
+//	tools:
+//	  - name: ffuf graphql
+//	    tool: ffuf
+//	    args: ["-u", "{in}/FUZZ", "-w", "{base}/wordlist/graphql.txt", "-o", "{out}"]
+//	    input: hosts
+//	    output: "{results}/ffuf.json"
+type customModeSpec struct {
+	Name    string           `yaml:"name" json:"name"`
+	ID      int              `yaml:"id" json:"id"`
+	Title   string           `yaml:"title" json:"title"`
+	Label   string           `yaml:"label" json:"label"`
+	Aliases []string         `yaml:"aliases" json:"aliases"`
+	GF      string           `yaml:"gf" json:"gf"`
+	Nuclei  *customNuclei    `yaml:"nuclei" json:"nuclei"`
+	Tools   []customToolSpec `yaml:"tools" json:"tools"`
+}
+
+type customNuclei struct {
+	Tags      []string `yaml:"tags" json:"tags"`
+	Templates []string `yaml:"templates" json:"templates"`
+	Severity  string   `yaml:"severity" json:"severity"`
+	Input     string   `yaml:"input" json:"input"`
+	Args      []string `yaml:"args" json:"args"`
+}
+
+type customToolSpec struct {
+	Name   string   `yaml:"name" json:"name"`
+	Tool   string   `yaml:"tool" json:"tool"`
+	Args   []string `yaml:"args" json:"args"`
+	Shell  string   `yaml:"shell" json:"shell"`
+	Input  string   `yaml:"input" json:"input"`
+	Output string   `yaml:"output" json:"output"`
+}
+
+// customChains berisi mode dari file user, diisi oleh LoadCustomModes.
+var customChains []Chain
+
+var customNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// CustomModesDir returns the directory scanned for user-defined modes.
+func CustomModesDir() string {
+	return filepath.Join(buildBugxBaseDir(), "modes")
+}
+
+// customIDsFile -> ~/BUGx/mode_ids.json: nama mode custom -> nomor menu yang
+// pernah diberikan, supaya nomor tidak bergeser saat file ditambah / dihapus.
+func customIDsFile() string {
+	return filepath.Join(buildBugxBaseDir(), "mode_ids.json")
+}
+
+// LoadCustomModes membaca semua definisi mode di CustomModesDir dan
+// mendaftarkannya setelah mode bawaan. File yang tidak valid dilewati dan
+// dilaporkan lewat slice error; folder yang tidak ada bukan error.
+func LoadCustomModes() []error {
+	return loadCustomModes(CustomModesDir(), customIDsFile())
+}
+
+func loadCustomModes(dir, idsPath string) []error {
+	customChains = nil
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return []error{err}
+	}
+
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(files)
+
+	var errs []error
+	var loaded []customMode
+	for _, f := range files {
+		base := filepath.Base(f)
+		c, err := loadCustomMode(f)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s dilewati: %w", base, err))
+			continue
+		}
+		if renamed, warn := avoidBuiltinNames(&c); renamed {
+			errs = append(errs, fmt.Errorf("%s: %s", base, warn))
+		}
+		if clash := modeNameTaken(c); clash != "" {
+			errs = append(errs, fmt.Errorf("%s dilewati: nama mode %q sudah dipakai", base, clash))
+			continue
+		}
+		// Didaftarkan sementara supaya modeNameTaken melihat nama file
+		// sebelumnya; nomor diberikan setelah semua file dibaca.
+		customChains = append(customChains, c)
+		loaded = append(loaded, customMode{Chain: c, file: base})
+	}
+	customChains = nil
+
+	ids, err := loadCustomIDs(idsPath)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w; nomor mode custom dibuat ulang", filepath.Base(idsPath), err))
+	}
+	chains, changed, idErrs := assignCustomIDs(loaded, ids)
+	errs = append(errs, idErrs...)
+	if changed {
+		if err := saveCustomIDs(idsPath, ids); err != nil {
+			errs = append(errs, fmt.Errorf("nomor mode custom tidak tersimpan: %w", err))
+		}
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i].ID < chains[j].ID })
+	customChains = chains
+	return errs
+}
+
+// customMode adalah mode custom yang sudah diparse beserta nama file asalnya.
+type customMode struct {
+	Chain
+	file string
+}
+
+// assignCustomIDs memberi nomor menu setiap mode: "id:" di file, lalu nomor
+// yang tersimpan di ids untuk nama itu, lalu nomor kosong terkecil yang belum
+// pernah dipakai mode lain. ids diperbarui in-place; changed = perlu disimpan.
+func assignCustomIDs(modes []customMode, ids map[string]int) (out []Chain, changed bool, errs []error) {
+	taken := make(map[int]bool)
+	pending := make([]bool, len(modes))
+	claim := func(i, id int) bool {
+		if id < CustomModeFirst || id > CustomModeLast || taken[id] {
+			return false
+		}
+		taken[id] = true
+		modes[i].ID = id
+		pending[i] = false
+		if ids[modes[i].Name] != id {
+			ids[modes[i].Name] = id
+			changed = true
+		}
+		return true
+	}
+	for i := range modes {
+		pending[i] = true
+		if want := modes[i].ID; want != 0 && !claim(i, want) {
+			errs = append(errs, fmt.Errorf("%s: id %d tidak bisa dipakai (di luar %d-%d atau sudah dipakai), nomor lain diberikan",
+				modes[i].file, want, CustomModeFirst, CustomModeLast))
+		}
+	}
+	for i := range modes {
+		if pending[i] {
+			claim(i, ids[modes[i].Name])
+		}
+	}
+
+	// Nomor milik mode yang filenya sudah dihapus tetap dicadangkan selama
+	// masih ada nomor lain; baru dipakai ulang bila rentang penuh.
+	reserved := make(map[int]bool)
+	for _, id := range ids {
+		reserved[id] = true
+	}
+	free := func(skipReserved bool) int {
+		for id := CustomModeFirst; id <= CustomModeLast; id++ {
+			if !taken[id] && !(skipReserved && reserved[id]) {
+				return id
+			}
+		}
+		return 0
+	}
+	for i := range modes {
+		if !pending[i] {
+			out = append(out, modes[i].Chain)
+			continue
+		}
+		id := free(true)
+		if id == 0 {
+			id = free(false)
+		}
+		if id == 0 {
+			errs = append(errs, fmt.Errorf("%s dilewati: maksimal %d mode custom (nomor %d-%d)",
+				modes[i].file, CustomModeLast-CustomModeFirst+1, CustomModeFirst, CustomModeLast))
+			continue
+		}
+		if old := ids[modes[i].Name]; old != 0 {
+			errs = append(errs, fmt.Errorf("%s: nomor %d kini dipakai mode lain, mode %s pindah ke %d",
+				modes[i].file, old, modes[i].Name, id))
+		}
+		for name, old := range ids {
+			if old == id && name != modes[i].Name {
+				delete(ids, name)
+			}
+		}
+		claim(i, id)
+		out = append(out, modes[i].Chain)
+	}
+	return out, changed, errs
+}
+
+// loadCustomIDs membaca peta nama -> nomor mode custom (file tidak ada =
+// peta kosong).
+func loadCustomIDs(path string) (map[string]int, error) {
+	ids := make(map[string]int)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ids, nil
+		}
+		return ids, err
+	}
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return make(map[string]int), err
+	}
+	return ids, nil
+}
+
+func saveCustomIDs(path string, ids map[string]int) error {
+	data, err := json.MarshalIndent(ids, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// avoidBuiltinNames menangani mode custom yang namanya kini dipakai mode
+// bawaan (mis. "cors" dari sebelum mode CORS ada): mode tetap dimuat dengan
+// nomor yang sama sebagai "<name>-custom", alias yang bentrok dibuang.
+func avoidBuiltinNames(c *Chain) (bool, string) {
+	var notes []string
+	if builtinName(c.Name) {
+		old := c.Name
+		c.Name += "-custom"
+		notes = append(notes, fmt.Sprintf("nama %q kini dipakai mode bawaan, mode ini tersedia sebagai %q", old, c.Name))
+	}
+	var aliases []string
+	for _, a := range c.Aliases {
+		if builtinName(a) {
+			notes = append(notes, fmt.Sprintf("alias %q dibuang (dipakai mode bawaan)", a))
+			continue
+		}
+		aliases = append(aliases, a)
+	}
+	c.Aliases = aliases
+	if len(notes) == 0 {
+		return false, ""
+	}
+	return true, strings.Join(notes, "; ") + "; ganti name / aliases di file untuk menghilangkan peringatan ini"
+}
+
+// builtinName reports whether name adalah nama / alias mode bawaan.
+func builtinName(name string) bool {
+	for _, c := range builtinChains {
+		if c.Name == name {
+			return true
+		}
+		for _, a := range c.Aliases {
+			if a == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// loadCustomMode mem-parse satu file dan membangunnya menjadi Chain.
+func loadCustomMode(path string) (Chain, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Chain{}, err
+	}
+
+	var spec customModeSpec
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &spec)
+	} else {
+		err = yaml.Unmarshal(data, &spec)
+	}
+	if err != nil {
+		return Chain{}, err
+	}
+	return spec.build()
+}
+
+// build mengubah spec menjadi Chain memakai builder yang sama dengan mode
+// bawaan (paramChain / hostChain / nucleiStep).
+func (s customModeSpec) build() (Chain, error) {
+	name := strings.ToLower(strings.TrimSpace(s.Name))
+	if !customNameRe.MatchString(name) {
+		return Chain{}, fmt.Errorf("name wajib diisi (huruf kecil, angka, - atau _)")
+	}
+
+	title := strings.TrimSpace(s.Title)
+	if title == "" {
+		title = strings.ToUpper(name)
+	}
+	label := strings.TrimSpace(s.Label)
+	if label == "" {
+		label = strings.ToUpper(name)
+	}
+
+	var steps []Step
+	if s.Nuclei != nil {
+		var extra []string
+		for _, t := range s.Nuclei.Templates {
+			extra = append(extra, "-t", expandHome(t))
+		}
+		if len(s.Nuclei.Tags) > 0 {
+			extra = append(extra, "-tags", strings.Join(s.Nuclei.Tags, ","))
+		}
+		if s.Nuclei.Severity != "" {
+			extra = append(extra, "--severity", s.Nuclei.Severity)
+		}
+		extra = append(extra, s.Nuclei.Args...)
+		if len(s.Nuclei.Tags) == 0 && len(s.Nuclei.Templates) == 0 {
+			return Chain{}, fmt.Errorf("nuclei butuh minimal satu tags atau templates")
+		}
+		inputs, err := customInputs(s.Nuclei.Input, name, s.GF != "")
+		if err != nil {
+			return Chain{}, err
+		}
+		steps = append(steps, nucleiStep(inputs, extra...))
+	}
+
+	for i, t := range s.Tools {
+		st, err := t.build(name, s.GF != "")
+		if err != nil {
+			return Chain{}, fmt.Errorf("tools[%d]: %w", i, err)
+		}
+		steps = append(steps, st)
+	}
+
+	var c Chain
+	if gf := strings.TrimSpace(s.GF); gf != "" {
+		c = paramChain(0, name, label, "MODE "+title, gf, steps...)
+	} else {
+		if len(steps) == 0 {
+			return Chain{}, fmt.Errorf("mode tidak punya step (isi gf, nuclei, atau tools)")
+		}
+		c = hostChain(0, name, label, "MODE "+title, steps...)
+	}
+	c.Menu = title + " (custom)"
+	c.ID = s.ID
+	for _, a := range s.Aliases {
+		c.Aliases = append(c.Aliases, strings.ToLower(strings.TrimSpace(a)))
+	}
+	for _, st := range c.Steps {
+		for _, in := range st.Inputs {
+			if in == "{urls}" {
+				c.NeedsURLs = true
+			}
+		}
+	}
+	return c, nil
+}
+
+func (t customToolSpec) build(mode string, hasGF bool) (Step, error) {
+	tool := strings.TrimSpace(t.Tool)
+	if tool == "" {
+		return Step{}, fmt.Errorf("tool wajib diisi")
+	}
+	if len(t.Args) == 0 && t.Shell == "" {
+		return Step{}, fmt.Errorf("isi args atau shell untuk %s", tool)
+	}
+
+	st := Step{
+		Name:   t.Name,
+		Tool:   tool,
+		Args:   t.Args,
+		Shell:  t.Shell,
+		Output: t.Output,
+	}
+	if st.Name == "" {
+		st.Name = tool
+	}
+	if st.Output == "" {
+		st.Output = "{results}/" + sanitizeForPath(tool) + ".txt"
+	}
+	if t.Input != "" {
+		inputs, err := customInputs(t.Input, mode, hasGF)
+		if err != nil {
+			return Step{}, err
+		}
+		st.Inputs = inputs
+	}
+	return st, nil
+}
+
+// customInputs memetakan nama input di file mode ke kandidat placeholder.
+func customInputs(input, mode string, hasGF bool) ([]string, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "":
+		if hasGF {
+			return paramInputs, nil
+		}
+		return hostInputs, nil
+	case "urls":
+		return paramInputs, nil
+	case "hosts":
+		return hostInputs, nil
+	case "subs":
+		return []string{"{subs}"}, nil
+	case "gf":
+		return []string{"{work}/gf_" + mode + ".txt"}, nil
+	case "clean":
+		return []string{"{work}/clean_" + mode + ".txt", "{work}/gf_" + mode + ".txt"}, nil
+	default:
+		return nil, fmt.Errorf("input tidak dikenal: %s (urls|hosts|subs|gf|clean)", input)
+	}
+}
+
+// modeNameTaken mengembalikan nama/alias c yang bentrok dengan mode lain.
+func modeNameTaken(c Chain) string {
+	for _, n := range append([]string{c.Name}, c.Aliases...) {
+		if _, ok := ModeByName(n); ok {
+			return n
+		}
+	}
+	return ""
+}
+
+// expandHome mengganti awalan ~/ dengan home directory.
+func expandHome(p string) string {
+	if !strings.HasPrefix(p, "~/") {
+		return p
+	}
+	return filepath.Join(filepath.Dir(buildBugxBaseDir()), p[2:])
+}
@@ -0,0 +1,165 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// modeFiles menyamakan isi dir dengan files (file lain dihapus).
+func modeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if _, keep := files[e.Name()]; !keep {
+			os.Remove(filepath.Join(dir, e.Name()))
+		}
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func wantModeIDs(t *testing.T, step string, want map[string]int) {
+	t.Helper()
+	for name, id := range want {
+		if got, ok := ModeByName(name); !ok || got != id {
+			t.Errorf("%s: ModeByName(%q) = %d, %v; want %d", step, name, got, ok, id)
+		}
+	}
+}
+
+func TestLoadCustomModesStableIDs(t *testing.T) {
+	dir := t.TempDir()
+	ids := filepath.Join(t.TempDir(), "mode_ids.json")
+	t.Cleanup(func() { customChains = nil })
+	const graphql = "name: graphql\nnuclei:\n  tags: [graphql]\n"
+	const last = `{"name": "last", "nuclei": {"tags": ["x"]}}`
+
+	modeFiles(t, dir, map[string]string{"b_graphql.yaml": graphql, "e_last.json": last})
+	if errs := loadCustomModes(dir, ids); len(errs) != 0 {
+		t.Fatalf("errs = %v", errs)
+	}
+	wantModeIDs(t, "awal", map[string]int{"graphql": 10, "last": 11})
+
+	// File yang urut lebih awal, file rusak dan nama duplikat tidak menggeser
+	// nomor yang sudah ada.
+	modeFiles(t, dir, map[string]string{
+		"a_first.yaml":   "name: first\nnuclei:\n  tags: [a]\n",
+		"a_broken.yaml":  "name: broken\n",
+		"a_dup.yaml":     graphql,
+		"b_graphql.yaml": graphql,
+		"e_last.json":    last,
+	})
+	loadCustomModes(dir, ids)
+	wantModeIDs(t, "tambah file", map[string]int{"graphql": 10, "last": 11, "first": 12})
+
+	// Menghapus file tidak menggeser mode lain; nomornya dicadangkan.
+	modeFiles(t, dir, map[string]string{
+		"a_first.yaml": "name: first\nnuclei:\n  tags: [a]\n",
+		"e_last.json":  last,
+		"z_new.yaml":   "name: new\nnuclei:\n  tags: [n]\n",
+	})
+	loadCustomModes(dir, ids)
+	wantModeIDs(t, "hapus file", map[string]int{"last": 11, "first": 12, "new": 13})
+	if IsMode(10) {
+		t.Error("nomor 10 (graphql yang dihapus) tidak boleh dipakai mode lain")
+	}
+
+	// Mode yang dikembalikan mendapat nomor lamanya lagi.
+	modeFiles(t, dir, map[string]string{
+		"a_first.yaml":   "name: first\nnuclei:\n  tags: [a]\n",
+		"b_graphql.yaml": graphql,
+		"e_last.json":    last,
+		"z_new.yaml":     "name: new\nnuclei:\n  tags: [n]\n",
+	})
+	loadCustomModes(dir, ids)
+	wantModeIDs(t, "kembalikan file", map[string]int{"graphql": 10, "last": 11, "first": 12, "new": 13})
+
+	// Modes() urut nomor.
+	var got []int
+	for _, m := range Modes() {
+		if m.Custom {
+			got = append(got, m.ID)
+		}
+	}
+	if len(got) != 4 || got[0] != 10 || got[3] != 13 {
+		t.Errorf("Modes() custom = %v", got)
+	}
+}
+
+func TestLoadCustomModesExplicitID(t *testing.T) {
+	dir := t.TempDir()
+	ids := filepath.Join(t.TempDir(), "mode_ids.json")
+	t.Cleanup(func() { customChains = nil })
+
+	modeFiles(t, dir, map[string]string{
+		"a.yaml": "name: alpha\nid: 30\nnuclei:\n  tags: [a]\n",
+		"b.yaml": "name: beta\nnuclei:\n  tags: [b]\n",
+		"c.yaml": "name: gamma\nid: 5\nnuclei:\n  tags: [c]\n",
+	})
+	errs := loadCustomModes(dir, ids)
+	wantModeIDs(t, "id eksplisit", map[string]int{"alpha": 30, "beta": 10, "gamma": 11})
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "c.yaml: id 5 tidak bisa dipakai") {
+		t.Errorf("errs = %v", errs)
+	}
+
+	// id eksplisit yang merebut nomor mode lain: mode lain pindah dengan peringatan.
+	modeFiles(t, dir, map[string]string{
+		"a.yaml": "name: alpha\nid: 30\nnuclei:\n  tags: [a]\n",
+		"b.yaml": "name: beta\nnuclei:\n  tags: [b]\n",
+		"c.yaml": "name: gamma\nid: 10\nnuclei:\n  tags: [c]\n",
+	})
+	errs = loadCustomModes(dir, ids)
+	wantModeIDs(t, "id bentrok", map[string]int{"alpha": 30, "gamma": 10, "beta": 11})
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "mode beta pindah ke 11") {
+		t.Errorf("errs = %v", errs)
+	}
+}
+
+func TestLoadCustomModesWarnings(t *testing.T) {
+	dir := t.TempDir()
+	modeFiles(t, dir, map[string]string{
+		"a_cors.yaml":    "name: cors\naliases: [origin, js]\nnuclei:\n  tags: [cors]\n",
+		"b_graphql.yaml": "name: graphql\nnuclei:\n  tags: [graphql]\n",
+		"c_dup.yaml":     "name: graphql\nnuclei:\n  tags: [graphql]\n",
+		"d_broken.yaml":  "name: broken\n",
+	})
+	t.Cleanup(func() { customChains = nil })
+
+	errs := loadCustomModes(dir, filepath.Join(t.TempDir(), "mode_ids.json"))
+
+	wantModeIDs(t, "peringatan", map[string]int{"cors-custom": 10, "origin": 10, "graphql": 11})
+	for name, id := range map[string]int{"cors": ModeCORS, "js": ModeJS} {
+		if got, _ := ModeByName(name); got != id {
+			t.Errorf("ModeByName(%q) = %d, want mode bawaan %d", name, got, id)
+		}
+	}
+
+	var msgs []string
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	joined := strings.Join(msgs, "\n")
+	for _, want := range []string{
+		`a_cors.yaml: nama "cors" kini dipakai mode bawaan`,
+		`alias "js" dibuang`,
+		"c_dup.yaml dilewati",
+		"d_broken.yaml dilewati",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("peringatan tidak memuat %q:\n%s", want, joined)
+		}
+	}
+}
+
+func TestBuiltinIDsOutsideCustomRange(t *testing.T) {
+	for _, c := range builtinChains {
+		if c.ID == ModeRunAll || (c.ID >= CustomModeFirst && c.ID <= CustomModeLast) {
+			t.Errorf("mode bawaan %s memakai nomor %d (dicadangkan)", c.Name, c.ID)
+		}
+	}
+}
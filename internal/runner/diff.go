@@ -0,0 +1,112 @@
+package runner
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/D0Lv-1N/BUGx/internal/diff"
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+)
+
+// compareWithPrevious membandingkan corpus recon dan temuan run ini dengan
+// run selesai sebelumnya untuk domain yang sama. Baseline dipilih per mode
+// dan per file corpus: run terakhir yang menjalankan mode itu / punya file
+// itu, sehingga run mode lain di antaranya tidak mereset "baru". Temuan baru
+// ditandai New; daftar aset baru ditulis ke new_*.txt di folder corpus run ini.
+func compareWithPrevious(res *Result, rc *reconResult) *diff.Run {
+	d := &diff.Run{}
+	prevRuns := previousRuns(res)
+	if len(prevRuns) > 0 {
+		d.PrevID = prevRuns[0].ID
+	}
+
+	corpus := func(cur string) diff.Assets {
+		if !fileExists(cur) {
+			return diff.Assets{}
+		}
+		name := filepath.Base(cur)
+		a, err := diff.Lines(prevCorpusFile(prevRuns, name), cur, filepath.Join(rc.Corpus, "new_"+name))
+		if err != nil {
+			fmt.Printf("[DIFF] [WARN] %s: %v\n", name, err)
+		}
+		return a
+	}
+	d.Subs = corpus(rc.Subs)
+	d.Hosts = corpus(rc.Hosts)
+	d.URLs = corpus(rc.URLs)
+	d.NewFindings = diff.MarkNew(res.Findings, prevFindings(prevRuns, res.Modes, LoadRun))
+	return d
+}
+
+// previousRuns returns the index entries of completed runs for the same
+// domain that started before res, newest first.
+func previousRuns(res *Result) []RunEntry {
+	runs, _ := ListRuns(res.Domain)
+	var out []RunEntry
+	for _, r := range runs {
+		if r.ID == res.ID || r.Status() != RunDone || !r.Start.Before(res.Start) {
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// prevCorpusFile returns the newest corpus file name among runs ("" = none).
+// Run tanpa mode yang butuh URL tidak punya gau.txt dan dilewati.
+func prevCorpusFile(runs []RunEntry, name string) string {
+	for _, r := range runs {
+		dir := r.Corpus
+		if dir == "" {
+			continue
+		}
+		if p := filepath.Join(dir, name); fileExists(p) {
+			return p
+		}
+	}
+	return ""
+}
+
+// prevFindings collects, for each mode, the findings of the newest run among
+// runs that included that mode. Hanya run pembanding itu yang dibaca lengkap
+// (load), masing-masing sekali.
+func prevFindings(runs []RunEntry, modes []string, load func(id string) (Result, error)) []findings.Finding {
+	var out []findings.Finding
+	loaded := make(map[string]Result)
+	for _, m := range modes {
+		for _, e := range runs {
+			if !slices.Contains(e.Modes, m) {
+				continue
+			}
+			r, ok := loaded[e.ID]
+			if !ok {
+				var err error
+				if r, err = load(e.ID); err != nil {
+					fmt.Printf("[DIFF] [WARN] %v\n", err)
+				}
+				loaded[e.ID] = r
+			}
+			for _, f := range r.Findings {
+				if f.Mode == m {
+					out = append(out, f)
+				}
+			}
+			break
+		}
+	}
+	return out
+}
+
+// OnlyNew returns a copy of r whose findings are limited to those not seen in
+// the previous run (--only-new).
+func (r Result) OnlyNew() Result {
+	out := r
+	out.Findings = nil
+	for _, f := range r.Findings {
+		if f.New {
+			out.Findings = append(out.Findings, f)
+		}
+	}
+	return out
+}
@@ -0,0 +1,83 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/D0Lv-1N/BUGx/internal/diff"
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+)
+
+// xss -> sqli -> xss: run sqli di antaranya tidak boleh membuat temuan XSS
+// lama dihitung baru lagi, dan gau.txt dibanding dengan run xss pertama.
+func TestPreviousBaselinePerMode(t *testing.T) {
+	dir := t.TempDir()
+	xssDir := filepath.Join(dir, "xss-run")
+	sqliDir := filepath.Join(dir, "sqli-run")
+	for _, f := range []string{
+		filepath.Join(xssDir, "subs.txt"),
+		filepath.Join(xssDir, "gau.txt"),
+		filepath.Join(sqliDir, "subs.txt"),
+	} {
+		if err := os.MkdirAll(filepath.Dir(f), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(f, []byte("x\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	oldXSS := findings.Finding{Tool: "dalfox", Mode: "xss", ID: "V", URL: "https://a.com/?q=1", Param: "q"}
+	oldSQLi := findings.Finding{Tool: "sqlmap", Mode: "sqli", ID: "boolean", URL: "https://a.com/item", Param: "id"}
+	// Terbaru lebih dulu, seperti previousRuns.
+	full := map[string]Result{
+		"2": {ID: "2", Modes: []string{"sqli"}, Findings: []findings.Finding{oldSQLi}},
+		"1": {ID: "1", Modes: []string{"xss"}, Findings: []findings.Finding{oldXSS}},
+	}
+	runs := []RunEntry{
+		{ID: "2", Modes: []string{"sqli"}, Corpus: sqliDir},
+		{ID: "1", Modes: []string{"xss"}, Corpus: xssDir},
+	}
+	var loads []string
+	load := func(id string) (Result, error) {
+		loads = append(loads, id)
+		return full[id], nil
+	}
+
+	if got, want := prevCorpusFile(runs, "subs.txt"), filepath.Join(sqliDir, "subs.txt"); got != want {
+		t.Errorf("prevCorpusFile(subs.txt) = %q, want %q", got, want)
+	}
+	if got, want := prevCorpusFile(runs, "gau.txt"), filepath.Join(xssDir, "gau.txt"); got != want {
+		t.Errorf("prevCorpusFile(gau.txt) = %q, want %q (run sqli tanpa gau dilewati)", got, want)
+	}
+	if got := prevCorpusFile(runs, "hosts.txt"); got != "" {
+		t.Errorf("prevCorpusFile(hosts.txt) = %q, want kosong", got)
+	}
+
+	newXSS := oldXSS
+	newXSS.URL = "https://a.com/search?q=1"
+	cur := []findings.Finding{oldXSS, newXSS}
+	if n := diff.MarkNew(cur, prevFindings(runs, []string{"xss"}, load)); n != 1 {
+		t.Fatalf("MarkNew = %d, want 1", n)
+	}
+	if cur[0].New || !cur[1].New {
+		t.Errorf("New = %v, %v; want false, true", cur[0].New, cur[1].New)
+	}
+
+	// Mode yang belum pernah dijalankan: belum ada baseline.
+	if got := prevFindings(runs, []string{"cors"}, load); len(got) != 0 {
+		t.Errorf("prevFindings(cors) = %v, want kosong", got)
+	}
+	// Hanya temuan mode yang diminta yang diambil dari run pembanding.
+	if got := prevFindings(runs, []string{"sqli"}, load); len(got) != 1 || got[0].Mode != "sqli" {
+		t.Errorf("prevFindings(sqli) = %v", got)
+	}
+
+	// Setiap run pembanding dibaca lengkap sekali saja.
+	loads = nil
+	prevFindings(runs, []string{"xss", "sqli", "xss"}, load)
+	if len(loads) != 2 {
+		t.Errorf("load dipanggil %v, want sekali per run", loads)
+	}
+}
@@ -0,0 +1,69 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// killGrace adalah jeda antara sinyal terminate ke process group dan kill paksa.
+const killGrace = 5 * time.Second
+
+// runCommandLive executes a command under ctx and streams stdout/stderr live
+// ke stdout/stderr (terminal, prefixWriter, dan/atau log step). Saat ctx
+// dibatalkan (timeout / Ctrl-C) seluruh process group tool dimatikan. Nilai
+// header / cookie disensor di log (redactArgs).
+func runCommandLive(ctx context.Context, stdout, stderr io.Writer, name string, args ...string) error {
+	fmt.Fprintf(stdout, "[CMD] %s %s\n", name, strings.Join(redactArgs(args), " "))
+	return runLive(ctx, exec.Command(name, args...), stdout, stderr)
+}
+
+// runShellLive runs a shell command (for simple pipe chains) with live output.
+// sh -c dan semua proses di pipeline berada di satu process group, sehingga
+// pembatalan ikut menghentikan cat/gau/gf di dalamnya. env ditambahkan ke
+// environment proses (mis. HTTP_PROXY).
+func runShellLive(ctx context.Context, stdout, stderr io.Writer, line string, env []string) error {
+	fmt.Fprintf(stdout, "[SHELL] %s\n", redactURLs(line))
+	cmd := exec.Command("sh", "-c", line)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	return runLive(ctx, cmd, stdout, stderr)
+}
+
+// runLive menjalankan cmd di process group sendiri dan menunggu selesai atau
+// ctx dibatalkan. Error ctx (context.Canceled / DeadlineExceeded) diutamakan
+// agar pemanggil bisa membedakan timeout/interupsi dari exit code biasa.
+func runLive(ctx context.Context, cmd *exec.Cmd, stdout, stderr io.Writer) error {
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	setProcessGroup(cmd)
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+	}
+
+	terminateProcessGroup(cmd)
+	select {
+	case <-done:
+	case <-time.After(killGrace):
+		killProcessGroup(cmd)
+		<-done
+	}
+	// Sapu sisa anggota pipeline yang mengabaikan SIGTERM.
+	killProcessGroup(cmd)
+	return ctx.Err()
+}
@@ -0,0 +1,166 @@
+package runner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+)
+
+// indexFile adalah ringkasan semua run di HistoryDir, supaya daftar history
+// dan pencarian run sebelumnya (diff) tidak perlu membaca setiap record
+// lengkap. Nama berawalan titik tidak pernah bentrok dengan run ID.
+const indexFile = ".index.json"
+
+// RunEntry adalah ringkasan satu run di index history.
+type RunEntry struct {
+	ID      string         `json:"id"`
+	Target  string         `json:"target"`
+	Domain  string         `json:"domain"`
+	Modes   []string       `json:"modes"`
+	Start   time.Time      `json:"start"`
+	End     time.Time      `json:"end"`
+	Aborted bool           `json:"aborted"`
+	Counts  map[string]int `json:"counts,omitempty"` // jumlah temuan per severity
+	Corpus  string         `json:"corpus,omitempty"` // folder corpus recon (diff)
+}
+
+// Status returns the run state, sama dengan Result.Status.
+func (e RunEntry) Status() string {
+	return runStatus(e.End, e.Aborted)
+}
+
+func entryOf(res Result) RunEntry {
+	return RunEntry{
+		ID:      res.ID,
+		Target:  res.Target,
+		Domain:  res.Domain,
+		Modes:   res.Modes,
+		Start:   res.Start,
+		End:     res.End,
+		Aborted: res.Aborted,
+		Counts:  findings.CountBySeverity(res.Findings),
+		Corpus:  res.ResultsDirs[reconChain.Name],
+	}
+}
+
+// indexMu melindungi read-modify-write index antar goroutine (RunTargets
+// menyimpan beberapa run bersamaan).
+var indexMu sync.Mutex
+
+// updateIndex menambahkan / mengganti entri e di index dir.
+func updateIndex(dir string, e RunEntry) error {
+	indexMu.Lock()
+	defer indexMu.Unlock()
+	entries, _, _ := syncIndex(dir)
+	replaced := false
+	for i := range entries {
+		if entries[i].ID == e.ID {
+			entries[i], replaced = e, true
+			break
+		}
+	}
+	if !replaced {
+		entries = append(entries, e)
+	}
+	return writeIndex(dir, entries)
+}
+
+// readIndex returns all entries, newest first. Index yang hilang / rusak
+// dibangun ulang, dan record yang belum tercatat (mis. ditulis versi lama)
+// ditambahkan; hanya record itu yang dibaca lengkap.
+func readIndex(dir string) ([]RunEntry, []error) {
+	indexMu.Lock()
+	defer indexMu.Unlock()
+	entries, changed, errs := syncIndex(dir)
+	if changed {
+		_ = writeIndex(dir, entries)
+	}
+	sortEntries(entries)
+	return entries, errs
+}
+
+// syncIndex membaca index lalu mencocokkannya dengan file run di dir (hanya
+// nama file): entri tanpa file dibuang, file tanpa entri dibaca.
+func syncIndex(dir string) (entries []RunEntry, changed bool, errs []error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, []error{err}
+	}
+	onDisk := make(map[string]bool)
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() || name == indexFile || filepath.Ext(name) != ".json" {
+			continue
+		}
+		onDisk[strings.TrimSuffix(name, ".json")] = true
+	}
+
+	var stored []RunEntry
+	if data, err := os.ReadFile(filepath.Join(dir, indexFile)); err == nil {
+		if json.Unmarshal(data, &stored) != nil {
+			stored, changed = nil, true
+		}
+	} else {
+		changed = len(onDisk) > 0
+	}
+
+	seen := make(map[string]bool)
+	for _, e := range stored {
+		if !onDisk[e.ID] || seen[e.ID] {
+			changed = true
+			continue
+		}
+		seen[e.ID] = true
+		entries = append(entries, e)
+	}
+	for id := range onDisk {
+		if seen[id] {
+			continue
+		}
+		res, err := loadRunFile(filepath.Join(dir, id+".json"))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		entries = append(entries, entryOf(res))
+		changed = true
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	return entries, changed, errs
+}
+
+func writeIndex(dir string, entries []RunEntry) error {
+	sortEntries(entries)
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, indexFile), data)
+}
+
+func sortEntries(entries []RunEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Start.After(entries[j].Start)
+	})
+}
+
+// writeFileAtomic menulis ke file sementara lalu rename.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
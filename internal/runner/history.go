@@ -0,0 +1,198 @@
+package runner
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Run status values derived from a stored Result.
+const (
+	RunRunning = "running" // belum selesai (atau proses mati sebelum sempat mencatat)
+	RunDone    = "done"
+	RunAborted = "aborted"
+)
+
+// ErrRunNotFound is returned by LoadRun for an unknown run ID.
+var ErrRunNotFound = errors.New("run tidak ditemukan")
+
+// Status returns the run state recorded in history.
+func (r Result) Status() string {
+	return runStatus(r.End, r.Aborted)
+}
+
+func runStatus(end time.Time, aborted bool) string {
+	switch {
+	case end.IsZero():
+		return RunRunning
+	case aborted:
+		return RunAborted
+	default:
+		return RunDone
+	}
+}
+
+// Timing returns the run's wall-clock time and the summed duration of all
+// steps. Selisihnya adalah waktu yang dihemat eksekusi paralel (Workers > 1).
+func (r Result) Timing() (wall, steps time.Duration) {
+	wall = r.Elapsed
+	if wall == 0 && !r.End.IsZero() {
+		wall = r.End.Sub(r.Start)
+	}
+	for _, st := range r.Steps {
+		steps += st.Duration
+	}
+	return wall, steps
+}
+
+// HistoryDir -> ~/BUGx/history (satu file JSON per run + indexFile).
+func HistoryDir() string {
+	return filepath.Join(buildBugxBaseDir(), "history")
+}
+
+// newRunID -> YYYYMMDD-HHMMSS-xxxx (urut waktu, suffix acak agar run paralel
+// di detik yang sama tidak bentrok).
+func newRunID(t time.Time) string {
+	b := make([]byte, 2)
+	if _, err := rand.Read(b); err != nil {
+		b = []byte{byte(t.Nanosecond() >> 8), byte(t.Nanosecond())}
+	}
+	return t.Format("20060102-150405") + "-" + hex.EncodeToString(b)
+}
+
+func runPath(id string) (string, error) {
+	return runPathIn(HistoryDir(), id)
+}
+
+func runPathIn(dir, id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.HasPrefix(id, ".") {
+		return "", fmt.Errorf("run ID tidak valid: %q", id)
+	}
+	return filepath.Join(dir, id+".json"), nil
+}
+
+// SaveRun writes the run record to history and updates the index. Ditulis ke
+// file sementara lalu di-rename supaya record tidak pernah setengah jadi.
+func SaveRun(res Result) error {
+	return saveRun(HistoryDir(), res)
+}
+
+func saveRun(dir string, res Result) error {
+	path, err := runPathIn(dir, res.ID)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return err
+	}
+	return updateIndex(dir, entryOf(res))
+}
+
+// saveRunLogged menyimpan run; gagal menyimpan history tidak menggagalkan run.
+func saveRunLogged(res Result) {
+	if err := SaveRun(res); err != nil {
+		fmt.Printf("[WARN] Gagal menyimpan history run %s: %v\n", res.ID, err)
+	}
+}
+
+// LoadRun reads one run record by ID.
+func LoadRun(id string) (Result, error) {
+	path, err := runPath(id)
+	if err != nil {
+		return Result{}, err
+	}
+	return loadRunFile(path)
+}
+
+func loadRunFile(path string) (Result, error) {
+	var res Result
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return res, fmt.Errorf("%w: %s", ErrRunNotFound, strings.TrimSuffix(filepath.Base(path), ".json"))
+		}
+		return res, err
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return res, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return res, nil
+}
+
+// ListRuns returns the index entries of stored runs, newest first. domain
+// kosong = semua domain. Record lengkap dibaca dengan LoadRun.
+func ListRuns(domain string) (runs []RunEntry, errs []error) {
+	return listRuns(HistoryDir(), domain)
+}
+
+func listRuns(dir, domain string) (runs []RunEntry, errs []error) {
+	all, errs := readIndex(dir)
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	for _, e := range all {
+		if domain == "" || strings.EqualFold(e.Domain, domain) {
+			runs = append(runs, e)
+		}
+	}
+	return runs, errs
+}
+
+// LatestRun returns the newest stored run for domain.
+func LatestRun(domain string) (Result, bool) {
+	runs, _ := ListRuns(domain)
+	if len(runs) == 0 {
+		return Result{}, false
+	}
+	res, err := LoadRun(runs[0].ID)
+	return res, err == nil
+}
+
+// ForModes returns a copy of r restricted to the given mode IDs (step recon
+// ikut dibuang). ids kosong = r apa adanya.
+func (r Result) ForModes(ids []int) Result {
+	if len(ids) == 0 {
+		return r
+	}
+	keep := make(map[string]bool)
+	for _, id := range ids {
+		if c := chainByID(id); c != nil {
+			keep[c.Name] = true
+		}
+	}
+
+	out := r
+	out.Modes, out.Steps, out.Findings, out.ResultsDirs = nil, nil, nil, nil
+	for _, m := range r.Modes {
+		if keep[m] {
+			out.Modes = append(out.Modes, m)
+		}
+	}
+	for _, st := range r.Steps {
+		if keep[st.Mode] {
+			out.Steps = append(out.Steps, st)
+		}
+	}
+	for _, f := range r.Findings {
+		if keep[f.Mode] {
+			out.Findings = append(out.Findings, f)
+		}
+	}
+	for m, dir := range r.ResultsDirs {
+		if keep[m] {
+			if out.ResultsDirs == nil {
+				out.ResultsDirs = make(map[string]string)
+			}
+			out.ResultsDirs[m] = dir
+		}
+	}
+	return out
+}
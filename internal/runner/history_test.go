@@ -0,0 +1,82 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+)
+
+func TestHistoryIndex(t *testing.T) {
+	dir := t.TempDir()
+	t0 := time.Date(2026, 10, 16, 20, 0, 0, 0, time.UTC)
+	runs := []Result{
+		{ID: "r1", Domain: "example.com", Modes: []string{"xss"}, Start: t0, End: t0.Add(time.Minute),
+			Findings:    []findings.Finding{{Severity: "high"}, {Severity: "info"}},
+			ResultsDirs: map[string]string{reconChain.Name: "/res/recon/r1"}},
+		{ID: "r2", Domain: "other.com", Modes: []string{"sqli"}, Start: t0.Add(time.Hour), End: t0.Add(2 * time.Hour)},
+		{ID: "r3", Domain: "example.com", Modes: []string{"sqli"}, Start: t0.Add(2 * time.Hour)},
+	}
+	for _, r := range runs {
+		if err := saveRun(dir, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Run yang disimpan ulang (selesai) mengganti entrinya, bukan menambah.
+	runs[2].End = runs[2].Start.Add(time.Minute)
+	runs[2].Aborted = true
+	if err := saveRun(dir, runs[2]); err != nil {
+		t.Fatal(err)
+	}
+
+	got, errs := listRuns(dir, "EXAMPLE.com")
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	if len(got) != 2 || got[0].ID != "r3" || got[1].ID != "r1" {
+		t.Fatalf("listRuns = %+v", got)
+	}
+	if got[0].Status() != RunAborted || got[1].Status() != RunDone {
+		t.Errorf("status = %s, %s", got[0].Status(), got[1].Status())
+	}
+	if got[1].Counts[findings.SevHigh] != 1 || got[1].Corpus != "/res/recon/r1" {
+		t.Errorf("entry r1 = %+v", got[1])
+	}
+
+	// Daftar dibaca dari index: record yang rusak setelah diindeks tidak
+	// perlu di-parse untuk listing.
+	if err := os.WriteFile(filepath.Join(dir, "r2.json"), []byte("{rusak"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if all, errs := listRuns(dir, ""); len(all) != 3 || len(errs) != 0 {
+		t.Errorf("listRuns dari index = %d entri, errs %v", len(all), errs)
+	}
+
+	// Index hilang: dibangun ulang dari record; record rusak dilaporkan.
+	if err := os.Remove(filepath.Join(dir, indexFile)); err != nil {
+		t.Fatal(err)
+	}
+	all, errs := listRuns(dir, "")
+	if len(all) != 2 || len(errs) != 1 {
+		t.Errorf("rebuild = %d entri, errs %v; want 2 entri, 1 error", len(all), errs)
+	}
+	if _, err := os.Stat(filepath.Join(dir, indexFile)); err != nil {
+		t.Errorf("index tidak ditulis ulang: %v", err)
+	}
+
+	// Record yang dihapus hilang dari daftar; record tanpa entri ditambahkan.
+	os.Remove(filepath.Join(dir, "r1.json"))
+	if err := os.WriteFile(filepath.Join(dir, "r2.json"), []byte(`{"id":"r2","domain":"other.com"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	all, _ = listRuns(dir, "")
+	ids := map[string]bool{}
+	for _, e := range all {
+		ids[e.ID] = true
+	}
+	if len(all) != 2 || !ids["r2"] || !ids["r3"] {
+		t.Errorf("sinkron = %+v", all)
+	}
+}
@@ -0,0 +1,126 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// abortWindow: Ctrl-C kedua dalam jendela ini menghentikan seluruh run.
+const abortWindow = 3 * time.Second
+
+// interrupter menerjemahkan SIGINT selama run:
+//   - Ctrl-C saat step berjalan -> batalkan step itu, lanjut ke step berikutnya.
+//   - Ctrl-C lagi dalam abortWindow (atau saat tidak ada step) -> hentikan run.
+//
+// Beberapa step bisa aktif bersamaan (target paralel); Ctrl-C pertama
+// membatalkan semuanya.
+//
+// Karena tool berjalan di process group sendiri, SIGINT dari terminal hanya
+// diterima BUGx; tool dimatikan lewat pembatalan context step.
+type interrupter struct {
+	mu         sync.Mutex
+	cancelRun  context.CancelFunc
+	active     map[int]*activeStep
+	nextID     int
+	lastSignal time.Time
+
+	sigs chan os.Signal
+	stop chan struct{}
+}
+
+// activeStep adalah step yang sedang berjalan beserta cancel-nya.
+type activeStep struct {
+	name    string
+	cancel  context.CancelFunc
+	skipped bool
+}
+
+// newInterrupter mulai menangkap SIGINT sampai Close dipanggil.
+func newInterrupter(cancelRun context.CancelFunc) *interrupter {
+	in := &interrupter{
+		cancelRun: cancelRun,
+		active:    make(map[int]*activeStep),
+		sigs:      make(chan os.Signal, 1),
+		stop:      make(chan struct{}),
+	}
+	signal.Notify(in.sigs, os.Interrupt)
+	go in.loop()
+	return in
+}
+
+// Close berhenti menangkap SIGINT (perilaku default kembali aktif).
+func (in *interrupter) Close() {
+	signal.Stop(in.sigs)
+	close(in.stop)
+}
+
+func (in *interrupter) loop() {
+	for {
+		select {
+		case <-in.stop:
+			return
+		case <-in.sigs:
+			in.handle()
+		}
+	}
+}
+
+func (in *interrupter) handle() {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	now := time.Now()
+	repeated := !in.lastSignal.IsZero() && now.Sub(in.lastSignal) < abortWindow
+	in.lastSignal = now
+
+	if len(in.active) == 0 || repeated {
+		fmt.Println()
+		fmt.Println("[INT] Run dihentikan oleh user. Menyimpan hasil yang sudah ada...")
+		for _, st := range in.active {
+			st.cancel()
+		}
+		in.cancelRun()
+		return
+	}
+
+	var names []string
+	for _, st := range in.active {
+		names = append(names, st.name)
+		st.skipped = true
+		st.cancel()
+	}
+	sort.Strings(names)
+	fmt.Println()
+	fmt.Printf("[INT] Step '%s' dibatalkan, lanjut ke step berikutnya. Tekan Ctrl-C lagi dalam %s untuk menghentikan run.\n",
+		strings.Join(names, "', '"), abortWindow)
+}
+
+// begin mendaftarkan step aktif beserta cancel-nya. Return token untuk end.
+func (in *interrupter) begin(name string, cancel context.CancelFunc) int {
+	if in == nil {
+		return 0
+	}
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.nextID++
+	in.active[in.nextID] = &activeStep{name: name, cancel: cancel}
+	return in.nextID
+}
+
+// end melepas step aktif dan melaporkan apakah step itu di-skip oleh user.
+func (in *interrupter) end(id int) bool {
+	if in == nil {
+		return false
+	}
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	st, ok := in.active[id]
+	delete(in.active, id)
+	return ok && st.skipped
+}
@@ -0,0 +1,248 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+	"github.com/D0Lv-1N/BUGx/internal/jsscan"
+)
+
+// jsMaxFiles membatasi jumlah file JS yang diunduh per run.
+const jsMaxFiles = 1000
+
+// jsCrawlStep: katana crawl host hidup (parse JS, -jc) untuk menemukan file
+// JS yang tidak ada di arsip gau. Aset gambar/font tidak diikutkan.
+func jsCrawlStep() Step {
+	return Step{
+		Name: "katana (crawl)",
+		Tool: "katana",
+		Args: []string{
+			"-list", "{in}",
+			"-jc", "-d", "3",
+			"-ef", "png,jpg,jpeg,gif,svg,css,woff,woff2,ttf,ico",
+			"-c", "{speed}",
+			"-silent",
+			"-o", "{out}",
+		},
+		Inputs:     []string{"{hosts}"},
+		Output:     "{work}/crawl_js.txt",
+		TargetList: true, // crawler bisa mengikuti link ke domain lain
+	}
+}
+
+// jsStep: unduh file JS (crawl + gau) lalu ekstrak endpoint dan secret.
+func jsStep() Step {
+	return Step{
+		Name:   "js (harvest)",
+		Tool:   "js",
+		Run:    runJS,
+		Inputs: []string{"{work}/crawl_js.txt", "{urls}"},
+		Output: "{results}/js.json",
+	}
+}
+
+// jsHost adalah hasil per host yang disimpan di {results}/js/<host>.json.
+type jsHost struct {
+	Host      string     `json:"host"`
+	Files     []string   `json:"files"`
+	Endpoints []string   `json:"endpoints"`
+	APIs      []string   `json:"api_paths"`
+	Secrets   []jsSecret `json:"secrets"`
+}
+
+type jsSecret struct {
+	Rule     string   `json:"rule"`
+	Name     string   `json:"name"`
+	Severity string   `json:"severity"`
+	Value    string   `json:"value"`
+	Files    []string `json:"files"`
+}
+
+// jsHarvest mengumpulkan hasil semua file, de-duplikasi per host.
+type jsHarvest struct {
+	mu    sync.Mutex
+	hosts map[string]*jsHostSet
+}
+
+type jsHostSet struct {
+	files     []string
+	endpoints map[string]bool
+	secrets   map[string]*jsSecret // rule + nilai
+	order     []string
+}
+
+// add mencatat hasil satu file dan mengembalikan secret yang baru untuk host.
+func (h *jsHarvest) add(host, file string, res jsscan.Result) []jsscan.Secret {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	set := h.hosts[host]
+	if set == nil {
+		set = &jsHostSet{endpoints: make(map[string]bool), secrets: make(map[string]*jsSecret)}
+		h.hosts[host] = set
+	}
+	set.files = append(set.files, file)
+	for _, ep := range res.Endpoints {
+		set.endpoints[ep] = true
+	}
+	var fresh []jsscan.Secret
+	for _, s := range res.Secrets {
+		key := s.Rule.ID + "\x00" + s.Value
+		if prev, ok := set.secrets[key]; ok {
+			prev.Files = append(prev.Files, file)
+			continue
+		}
+		set.secrets[key] = &jsSecret{Rule: s.Rule.ID, Name: s.Rule.Name, Severity: s.Rule.Severity, Value: s.Value, Files: []string{file}}
+		set.order = append(set.order, key)
+		fresh = append(fresh, s)
+	}
+	return fresh
+}
+
+// write menyimpan satu file JSON per host ke dir; returns jumlah endpoint.
+func (h *jsHarvest) write(dir string) (int, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, err
+	}
+	total := 0
+	for host, set := range h.hosts {
+		rec := jsHost{Host: host, Files: set.files, Endpoints: []string{}, APIs: []string{}, Secrets: []jsSecret{}}
+		sort.Strings(rec.Files)
+		for ep := range set.endpoints {
+			rec.Endpoints = append(rec.Endpoints, ep)
+			if apiURLRe.MatchString(ep) {
+				rec.APIs = append(rec.APIs, ep)
+			}
+		}
+		sort.Strings(rec.Endpoints)
+		sort.Strings(rec.APIs)
+		for _, key := range set.order {
+			rec.Secrets = append(rec.Secrets, *set.secrets[key])
+		}
+		total += len(rec.Endpoints)
+
+		data, err := json.MarshalIndent(rec, "", "  ")
+		if err != nil {
+			return total, err
+		}
+		if err := os.WriteFile(filepath.Join(dir, jsHostFile(host)), append(data, '\n'), 0o644); err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// runJS adalah nativeFunc mode JS: in adalah hasil crawl (atau corpus gau
+// bila katana tidak ada); corpus gau selalu ikut dibaca bila tersedia.
+func runJS(ctx context.Context, env *chainEnv, in, out string, w io.Writer) error {
+	sources := []string{in}
+	if env.Recon.URLs != in && fileExists(env.Recon.URLs) {
+		sources = append(sources, env.Recon.URLs)
+	}
+	files, err := jsURLs(sources, jsMaxFiles)
+	if err != nil {
+		return err
+	}
+
+	sw := &syncWriter{w: w}
+	lim := newLimiter(env.Opts.effectiveRate())
+	defer lim.stop()
+	client := nativeProbe(env.Opts, lim)
+	client.MaxBody = jsscan.DefaultMaxBytes
+	fetcher := &jsscan.Fetcher{Client: client}
+	sw.printf("[JS] %d file JS (%s), %d rule secret\n", len(files), describeInputs(sources), len(jsscan.Rules))
+
+	harvest := &jsHarvest{hosts: make(map[string]*jsHostSet)}
+	var mu sync.Mutex
+	skipped := 0
+	err = scanTargets(ctx, env, files, out, sw, func(ctx context.Context, file string) ([]findings.Finding, error) {
+		body, err := fetcher.Fetch(ctx, file)
+		if errors.Is(err, jsscan.ErrNotJS) {
+			mu.Lock()
+			skipped++
+			mu.Unlock()
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var list []findings.Finding
+		for _, s := range harvest.add(hostOf(file), file, jsscan.Extract(body)) {
+			list = append(list, jsFinding(file, s))
+		}
+		return list, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(filepath.Dir(out), "js")
+	endpoints, err := harvest.write(dir)
+	if err != nil {
+		return err
+	}
+	if skipped > 0 {
+		sw.printf("[JS] %d URL dilewati (bukan 200 / bukan JavaScript)\n", skipped)
+	}
+	sw.printf("[JS] %d endpoint dari %d host -> %s\n", endpoints, len(harvest.hosts), dir)
+	return nil
+}
+
+// jsHostFile -> nama file hasil per host ("localhost:8080" -> "localhost_8080.json").
+func jsHostFile(host string) string {
+	return strings.NewReplacer(":", "_", "/", "_").Replace(host) + ".json"
+}
+
+// jsURLs mengambil URL .js dari list URL, unik per host+path (query versi
+// seperti app.js?v=123 dianggap file yang sama).
+func jsURLs(paths []string, limit int) ([]string, error) {
+	var out []string
+	seen := make(map[string]bool)
+	for _, p := range paths {
+		all, err := readURLs(p)
+		if err != nil {
+			return nil, err
+		}
+		for _, raw := range all {
+			u, err := url.Parse(raw)
+			if err != nil || u.Host == "" || !strings.HasSuffix(strings.ToLower(u.Path), ".js") {
+				continue
+			}
+			key := u.Host + u.Path
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			out = append(out, raw)
+			if len(out) >= limit {
+				return out, nil
+			}
+		}
+	}
+	return out, nil
+}
+
+// jsFinding: nilai secret disamarkan di temuan (laporan bisa dibagikan);
+// nilai lengkap hanya ada di {results}/js/<host>.json.
+func jsFinding(file string, s jsscan.Secret) findings.Finding {
+	return findings.Finding{
+		Tool:      "js",
+		ID:        "js-" + s.Rule.ID,
+		Name:      "Secret di JavaScript: " + s.Rule.Name,
+		Severity:  s.Rule.Severity,
+		URL:       file,
+		Evidence:  fmt.Sprintf("%s %s (nilai lengkap di js/%s)", s.Rule.Name, jsscan.Mask(s.Value), jsHostFile(hostOf(file))),
+		Curl:      fmt.Sprintf("curl -sk %s", escapeShell(file)),
+		Timestamp: time.Now(),
+	}
+}
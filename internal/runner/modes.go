@@ -1,13 +1,19 @@
 package runner
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"os/user"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/D0Lv-1N/BUGx/internal/diff"
+	"github.com/D0Lv-1N/BUGx/internal/findings"
 )
 
 // Mode identifiers for menu selection.
@@ -20,1066 +26,291 @@ const (
 	ModeSensitive = 6
 	ModeCMS       = 7
 	ModeRCE       = 8
-)
-
-// RunModes runs all selected modes sequentially for the given target and speed.
-// - Modes are expected to be normalized & sorted by the caller.
-// - Returns unique list of tool names that were actually invoked (for summary).
-func RunModes(modes []int, target string, speed int) []string {
-	used := make(map[string]struct{})
 
-	for _, m := range modes {
-		switch m {
-		case ModeXSS:
-			for _, t := range runXSSChain(target, speed) {
-				used[t] = struct{}{}
-			}
-		case ModeSQLi:
-			for _, t := range runSQLiChain(target, speed) {
-				used[t] = struct{}{}
-			}
-		case ModeLFI:
-			for _, t := range runLFIChain(target, speed) {
-				used[t] = struct{}{}
-			}
-		case ModeSSRF:
-			for _, t := range runSSRFChain(target, speed) {
-				used[t] = struct{}{}
-			}
-		case ModeRedirect:
-			for _, t := range runRedirectChain(target, speed) {
-				used[t] = struct{}{}
-			}
-		case ModeSensitive:
-			for _, t := range runSensitiveChain(target, speed) {
-				used[t] = struct{}{}
-			}
-		case ModeCMS:
-			for _, t := range runCMSChain(target, speed) {
-				used[t] = struct{}{}
-			}
-		case ModeRCE:
-			// RCE / High impact chains: nuclei critical/rce/takeover templates, etc.
-			for _, t := range runRCEChain(target, speed) {
-				used[t] = struct{}{}
-			}
-		default:
-			fmt.Printf("[INFO] Mode %d belum diimplementasikan.\n", m)
-		}
-	}
+	// ModeRunAll is the menu shortcut for every registered mode.
+	ModeRunAll = 9
+
+	// CustomModeFirst..CustomModeLast dicadangkan untuk mode custom
+	// (~/BUGx/modes), tetap per nama (lihat assignCustomIDs). Rentang ini tidak pernah dipakai mode
+	// bawaan supaya nomor yang sudah dipakai script / cron tidak bergeser.
+	CustomModeFirst = 10
+	CustomModeLast  = 49
+
+	// Mode bawaan yang ditambahkan setelah rentang custom.
+	ModeTakeover = 50
+	ModeCORS     = 51
+	ModeJS       = 52
+	ModeCRLF     = 53
+	ModeSSTI     = 54
+)
 
-	var tools []string
-	for t := range used {
-		tools = append(tools, t)
-	}
-	return tools
+// ModeInfo describes a registered mode for menus and CLI help.
+type ModeInfo struct {
+	ID     int
+	Name   string
+	Title  string
+	Custom bool
 }
 
-//
-// XSS MODE (1)
-//
-
-// runXSSChain:
-// - Fokus pada parameterized URLs untuk XSS
-// - Chain (adaptif, tergantung tools tersedia):
-//  1. subfinder -d domain -o subs.txt
-//  2. httpx -l subs.txt -mc 200 -o hosts.txt
-//  3. cat hosts.txt | gau --threads speed | gf xss > gf_xss.txt
-//  4. httpx -l gf_xss.txt -mc 200 -o clean_xss.txt
-//  5. nuclei -l clean_xss.txt --severity medium,high,critical -tags xss -o results/xss/.../nuclei.json
-//  6. dalfox file clean_xss.txt --skip-mining-all -w speed -o results/xss/.../dalfox.json
-func runXSSChain(target string, speed int) []string {
-	fmt.Println("========== [MODE XSS] ==========")
-	domain := extractDomain(target)
-	if domain == "" {
-		fmt.Printf("[XSS] Target tidak valid: %s\n", target)
-		fmt.Println("========== [/MODE XSS] =========")
-		return nil
-	}
-
-	tmpDir := buildTempDir(domain, "xss")
-	defer cleanupTempDir(tmpDir)
-
-	resultsDir := buildModeResultsDir("xss", domain)
-	_ = os.MkdirAll(resultsDir, 0o755)
-
-	subs := filepath.Join(tmpDir, "subs.txt")
-	hosts := filepath.Join(tmpDir, "hosts.txt")
-	gfXss := filepath.Join(tmpDir, "gf_xss.txt")
-	clean := filepath.Join(tmpDir, "clean_xss.txt")
-
-	var used []string
-
-	// 1) subfinder
-	if hasTool("subfinder") {
-		args := []string{"-d", domain, "-o", subs}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
-		}
-		logStep("XSS", "subfinder", args)
-		if err := runCommandLive("subfinder", args...); err == nil {
-			used = append(used, "subfinder")
-		} else {
-			logFail("XSS", "subfinder", err)
-		}
-	} else {
-		logMissing("XSS", "subfinder")
-	}
-
-	// 2) httpx (subs -> hosts)
-	if hasTool("httpx") && fileExists(subs) {
-		args := []string{
-			"-l", subs,
-			"-o", hosts,
-			"-mc", "200",
-		}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
-		}
-		logStep("XSS", "httpx (subs->hosts)", args)
-		if err := runCommandLive("httpx", args...); err == nil {
-			used = append(used, "httpx")
-		} else {
-			logFail("XSS", "httpx", err)
-		}
-	} else if !hasTool("httpx") {
-		logMissing("XSS", "httpx")
-	}
-
-	// 3) gau (hosts -> gau_xss)
-	if hasTool("gau") && fileExists(hosts) {
-		gauOut := filepath.Join(tmpDir, "gau_xss.txt")
-		line := fmt.Sprintf("cat %s | gau --threads %d --verbose > %s",
-			escapeShell(hosts),
-			maxInt(speed, 1),
-			escapeShell(gauOut),
-		)
-		logShell("XSS", line)
-		if err := runShellLive(line); err == nil {
-			used = append(used, "gau")
-		} else {
-			logFail("XSS", "gau xss", err)
-		}
-		// 4) gf xss (gau_xss -> gf_xss)
-		if hasTool("gf") && fileExists(gauOut) {
-			lineGF := fmt.Sprintf("cat %s | gf xss > %s",
-				escapeShell(gauOut),
-				escapeShell(gfXss),
-			)
-			logShell("XSS", lineGF)
-			if err := runShellLive(lineGF); err == nil {
-				used = append(used, "gf")
-			} else {
-				logFail("XSS", "gf xss", err)
-			}
-		} else if !hasTool("gf") {
-			logMissing("XSS", "gf")
-		}
-	} else {
-		if !fileExists(hosts) {
-			logInfo("XSS", "hosts.txt tidak ada, lewati gau+gf xss")
-		}
-		if !hasTool("gau") {
-			logMissing("XSS", "gau")
-		}
-		if !hasTool("gf") {
-			logMissing("XSS", "gf")
-		}
-	}
-
-	// 4) httpx filter lagi (gf_xss -> clean) setelah gf menghasilkan kandidat
-	if hasTool("httpx") && fileExists(gfXss) {
-		args := []string{
-			"-l", gfXss,
-			"-o", clean,
-			"-mc", "200",
-		}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
-		}
-		logStep("XSS", "httpx (gf_xss->clean)", args)
-		if err := runCommandLive("httpx", args...); err == nil {
-			used = append(used, "httpx")
-		} else {
-			logFail("XSS", "httpx gf_xss", err)
-		}
-	}
-
-	// Target list untuk vuln scan
-	// nuclei diarahkan ke hasil gau (gau_xss) atau hosts sebagai fallback,
-	// bukan ke hasil gf_xss agar cakupan tetap luas.
-	gauXss := filepath.Join(tmpDir, "gau_xss.txt")
-	list := chooseFirstExisting(gauXss, hosts)
-	if list == "" {
-		logInfo("XSS", "Tidak ada daftar URL kandidat, hentikan mode XSS.")
-		fmt.Println("========== [/MODE XSS] =========")
-		return unique(used)
-	}
-
-	// 5) nuclei
-	if hasTool("nuclei") {
-		out := filepath.Join(resultsDir, "nuclei.json")
-		args := []string{
-			"-l", list,
-			"--severity", "medium,high,critical",
-			"-tags", "xss",
-			"-o", out,
-		}
-		if speed > 0 {
-			args = append(args, "-c", fmt.Sprintf("%d", speed))
-		}
-		logStep("XSS", "nuclei", args)
-		if err := runCommandLive("nuclei", args...); err == nil {
-			used = append(used, "nuclei")
-		} else {
-			logFail("XSS", "nuclei", err)
-		}
-	} else {
-		logMissing("XSS", "nuclei")
-	}
-
-	// 6) dalfox
-	if hasTool("dalfox") {
-		out := filepath.Join(resultsDir, "dalfox.json")
-		args := []string{
-			"file", list,
-			"--skip-mining-all",
-			"--custom-payload", filepath.Join(buildBugxBaseDir(), "wordlist", "xss.txt"),
-			"-w", fmt.Sprintf("%d", maxInt(speed, 1)),
-			"-o", out,
-		}
-		logStep("XSS", "dalfox", args)
-		if err := runCommandLive("dalfox", args...); err == nil {
-			used = append(used, "dalfox")
-		} else {
-			logFail("XSS", "dalfox", err)
-		}
-	} else {
-		logMissing("XSS", "dalfox")
-	}
-
-	fmt.Println("========== [/MODE XSS] =========")
-	return unique(used)
+// Modes returns all registered modes (built-in first, then custom) in menu order.
+func Modes() []ModeInfo {
+	var out []ModeInfo
+	for _, c := range allChains() {
+		out = append(out, ModeInfo{
+			ID:     c.ID,
+			Name:   c.Name,
+			Title:  c.Menu,
+			Custom: isCustom(c),
+		})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
 }
 
-//
-// SQLi MODE (2)
-//
-
-func runSQLiChain(target string, speed int) []string {
-	fmt.Println("========== [MODE SQLi] =========")
-	domain := extractDomain(target)
-	if domain == "" {
-		fmt.Printf("[SQLi] Target tidak valid: %s\n", target)
-		fmt.Println("========== [/MODE SQLi] =========")
-		return nil
-	}
-
-	tmpDir := buildTempDir(domain, "sqli")
-	defer cleanupTempDir(tmpDir)
-
-	resultsDir := buildModeResultsDir("sqli", domain)
-	_ = os.MkdirAll(resultsDir, 0o755)
-
-	subs := filepath.Join(tmpDir, "subs.txt")
-	hosts := filepath.Join(tmpDir, "hosts.txt")
-	gfSQLi := filepath.Join(tmpDir, "gf_sqli.txt")
-	clean := filepath.Join(tmpDir, "clean_sqli.txt")
-
-	var used []string
-
-	// subfinder
-	if hasTool("subfinder") {
-		args := []string{"-d", domain, "-o", subs}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
-		}
-		logStep("SQLi", "subfinder", args)
-		if err := runCommandLive("subfinder", args...); err == nil {
-			used = append(used, "subfinder")
-		} else {
-			logFail("SQLi", "subfinder", err)
-		}
-	} else {
-		logMissing("SQLi", "subfinder")
-	}
-
-	// httpx (subs -> hosts)
-	if hasTool("httpx") && fileExists(subs) {
-		args := []string{"-l", subs, "-o", hosts, "-mc", "200"}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
-		}
-		logStep("SQLi", "httpx (subs->hosts)", args)
-		if err := runCommandLive("httpx", args...); err == nil {
-			used = append(used, "httpx")
-		} else {
-			logFail("SQLi", "httpx", err)
-		}
-	} else if !hasTool("httpx") {
-		logMissing("SQLi", "httpx")
-	}
-
-	// gau (hosts -> gau_sqli)
-	if hasTool("gau") && fileExists(hosts) {
-		gauOut := filepath.Join(tmpDir, "gau_sqli.txt")
-		line := fmt.Sprintf("cat %s | gau --threads %d --verbose > %s",
-			escapeShell(hosts),
-			maxInt(speed, 1),
-			escapeShell(gauOut),
-		)
-		logShell("SQLi", line)
-		if err := runShellLive(line); err == nil {
-			used = append(used, "gau")
-		} else {
-			logFail("SQLi", "gau sqli", err)
-		}
-		// gf sqli (gau_sqli -> gf_sqli)
-		if hasTool("gf") && fileExists(gauOut) {
-			lineGF := fmt.Sprintf("cat %s | gf sqli > %s",
-				escapeShell(gauOut),
-				escapeShell(gfSQLi),
-			)
-			logShell("SQLi", lineGF)
-			if err := runShellLive(lineGF); err == nil {
-				used = append(used, "gf")
-			} else {
-				logFail("SQLi", "gf sqli", err)
-			}
-		} else if !hasTool("gf") {
-			logMissing("SQLi", "gf")
-		}
-	} else {
-		if !fileExists(hosts) {
-			logInfo("SQLi", "hosts.txt tidak ada, lewati gau+gf sqli")
-		}
-		if !hasTool("gau") {
-			logMissing("SQLi", "gau")
-		}
-		if !hasTool("gf") {
-			logMissing("SQLi", "gf")
-		}
-	}
-
-	// httpx filter lagi
-	if hasTool("httpx") && fileExists(gfSQLi) {
-		args := []string{"-l", gfSQLi, "-o", clean, "-mc", "200"}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
-		}
-		logStep("SQLi", "httpx (gf_sqli->clean)", args)
-		if err := runCommandLive("httpx", args...); err == nil {
-			used = append(used, "httpx")
-		} else {
-			logFail("SQLi", "httpx gf_sqli", err)
-		}
-	}
-
-	// nuclei diarahkan ke hasil gau (gau_sqli) atau hosts sebagai fallback.
-	gauSQLi := filepath.Join(tmpDir, "gau_sqli.txt")
-	list := chooseFirstExisting(gauSQLi, hosts)
-	if list == "" {
-		logInfo("SQLi", "Tidak ada URL kandidat, hentikan mode SQLi.")
-		fmt.Println("========== [/MODE SQLi] =========")
-		return unique(used)
-	}
-
-	// nuclei -tags sqli
-	if hasTool("nuclei") {
-		out := filepath.Join(resultsDir, "nuclei.json")
-		args := []string{"-l", list, "-tags", "sqli", "-o", out}
-		if speed > 0 {
-			args = append(args, "-c", fmt.Sprintf("%d", speed))
-		}
-		logStep("SQLi", "nuclei", args)
-		if err := runCommandLive("nuclei", args...); err == nil {
-			used = append(used, "nuclei")
-		} else {
-			logFail("SQLi", "nuclei", err)
-		}
-	} else {
-		logMissing("SQLi", "nuclei")
-	}
-
-	// Untuk sqlmap: kita hanya siapkan input list; tidak auto-exploit agresif.
-	// (Bisa ditambahkan sebagai opsi manual di masa depan.)
-
-	fmt.Println("========== [/MODE SQLi] =========")
-	return unique(used)
+// IsMode reports whether id refers to a registered mode (excluding RUN ALL).
+func IsMode(id int) bool {
+	return chainByID(id) != nil
 }
 
-//
-// LFI/RFI MODE (3)
-//
-
-func runLFIChain(target string, speed int) []string {
-	fmt.Println("========== [MODE LFI/RFI] =========")
-	domain := extractDomain(target)
-	if domain == "" {
-		fmt.Printf("[LFI] Target tidak valid: %s\n", target)
-		fmt.Println("========== [/MODE LFI/RFI] =========")
-		return nil
-	}
-
-	tmpDir := buildTempDir(domain, "lfi")
-	defer cleanupTempDir(tmpDir)
-
-	resultsDir := buildModeResultsDir("lfi", domain)
-	_ = os.MkdirAll(resultsDir, 0o755)
-
-	subs := filepath.Join(tmpDir, "subs.txt")
-	hosts := filepath.Join(tmpDir, "hosts.txt")
-	gfLfi := filepath.Join(tmpDir, "gf_lfi.txt")
-	clean := filepath.Join(tmpDir, "clean_lfi.txt")
-
-	var used []string
-
-	// subfinder
-	if hasTool("subfinder") {
-		args := []string{"-d", domain, "-o", subs}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
-		}
-		logStep("LFI", "subfinder", args)
-		if err := runCommandLive("subfinder", args...); err == nil {
-			used = append(used, "subfinder")
-		} else {
-			logFail("LFI", "subfinder", err)
+// ModeByName resolves a CLI mode name or alias (case-insensitive) to its identifier.
+func ModeByName(name string) (int, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, c := range allChains() {
+		if c.Name == name {
+			return c.ID, true
 		}
-	} else {
-		logMissing("LFI", "subfinder")
-	}
-
-	// httpx
-	if hasTool("httpx") && fileExists(subs) {
-		args := []string{"-l", subs, "-o", hosts, "-mc", "200"}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
-		}
-		logStep("LFI", "httpx (subs->hosts)", args)
-		if err := runCommandLive("httpx", args...); err == nil {
-			used = append(used, "httpx")
-		} else {
-			logFail("LFI", "httpx", err)
-		}
-	} else if !hasTool("httpx") {
-		logMissing("LFI", "httpx")
-	}
-
-	// gau (hosts -> gau_lfi)
-	if hasTool("gau") && fileExists(hosts) {
-		gauOut := filepath.Join(tmpDir, "gau_lfi.txt")
-		line := fmt.Sprintf("cat %s | gau --threads %d --verbose > %s",
-			escapeShell(hosts),
-			maxInt(speed, 1),
-			escapeShell(gauOut),
-		)
-		logShell("LFI", line)
-		if err := runShellLive(line); err == nil {
-			used = append(used, "gau")
-		} else {
-			logFail("LFI", "gau lfi", err)
-		}
-		// gf lfi (gau_lfi -> gf_lfi)
-		if hasTool("gf") && fileExists(gauOut) {
-			lineGF := fmt.Sprintf("cat %s | gf lfi > %s",
-				escapeShell(gauOut),
-				escapeShell(gfLfi),
-			)
-			logShell("LFI", lineGF)
-			if err := runShellLive(lineGF); err == nil {
-				used = append(used, "gf")
-			} else {
-				logFail("LFI", "gf lfi", err)
+		for _, a := range c.Aliases {
+			if a == name {
+				return c.ID, true
 			}
-		} else if !hasTool("gf") {
-			logMissing("LFI", "gf")
-		}
-	}
-
-	// httpx filter lagi
-	if hasTool("httpx") && fileExists(gfLfi) {
-		args := []string{"-l", gfLfi, "-o", clean, "-mc", "200"}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
 		}
-		logStep("LFI", "httpx (gf_lfi->clean)", args)
-		if err := runCommandLive("httpx", args...); err == nil {
-			used = append(used, "httpx")
-		} else {
-			logFail("LFI", "httpx gf_lfi", err)
-		}
-	}
-
-	// nuclei diarahkan ke hasil gau (gau_lfi) atau hosts sebagai fallback.
-	gauLfi := filepath.Join(tmpDir, "gau_lfi.txt")
-	list := chooseFirstExisting(gauLfi, hosts)
-	if list == "" {
-		logInfo("LFI", "Tidak ada URL kandidat LFI.")
-		fmt.Println("========== [/MODE LFI/RFI] =========")
-		return unique(used)
-	}
-
-	// nuclei -tags lfi
-	if hasTool("nuclei") {
-		out := filepath.Join(resultsDir, "nuclei.json")
-		args := []string{"-l", list, "-tags", "lfi", "-o", out}
-		if speed > 0 {
-			args = append(args, "-c", fmt.Sprintf("%d", speed))
-		}
-		logStep("LFI", "nuclei", args)
-		if err := runCommandLive("nuclei", args...); err == nil {
-			used = append(used, "nuclei")
-		} else {
-			logFail("LFI", "nuclei", err)
-		}
-	} else {
-		logMissing("LFI", "nuclei")
 	}
-
-	fmt.Println("========== [/MODE LFI/RFI] =========")
-	return unique(used)
+	return 0, false
 }
 
-//
-// SSRF MODE (4)
-//
-
-func runSSRFChain(target string, speed int) []string {
-	fmt.Println("========== [MODE SSRF] =========")
-	domain := extractDomain(target)
-	if domain == "" {
-		fmt.Printf("[SSRF] Target tidak valid: %s\n", target)
-		fmt.Println("========== [/MODE SSRF] =========")
-		return nil
-	}
-
-	tmpDir := buildTempDir(domain, "ssrf")
-	defer cleanupTempDir(tmpDir)
-
-	resultsDir := buildModeResultsDir("ssrf", domain)
-	_ = os.MkdirAll(resultsDir, 0o755)
-
-	subs := filepath.Join(tmpDir, "subs.txt")
-	hosts := filepath.Join(tmpDir, "hosts.txt")
-	gfSSRF := filepath.Join(tmpDir, "gf_ssrf.txt")
-	clean := filepath.Join(tmpDir, "clean_ssrf.txt")
-
-	var used []string
-
-	// subfinder
-	if hasTool("subfinder") {
-		args := []string{"-d", domain, "-o", subs}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
-		}
-		logStep("SSRF", "subfinder", args)
-		if err := runCommandLive("subfinder", args...); err == nil {
-			used = append(used, "subfinder")
-		} else {
-			logFail("SSRF", "subfinder", err)
-		}
-	} else {
-		logMissing("SSRF", "subfinder")
-	}
-
-	// httpx
-	if hasTool("httpx") && fileExists(subs) {
-		args := []string{"-l", subs, "-o", hosts, "-mc", "200"}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
-		}
-		logStep("SSRF", "httpx (subs->hosts)", args)
-		if err := runCommandLive("httpx", args...); err == nil {
-			used = append(used, "httpx")
-		} else {
-			logFail("SSRF", "httpx", err)
-		}
-	} else if !hasTool("httpx") {
-		logMissing("SSRF", "httpx")
-	}
-
-	// gau (hosts -> gau_ssrf)
-	if hasTool("gau") && fileExists(hosts) {
-		gauOut := filepath.Join(tmpDir, "gau_ssrf.txt")
-		line := fmt.Sprintf("cat %s | gau --threads %d --verbose > %s",
-			escapeShell(hosts),
-			maxInt(speed, 1),
-			escapeShell(gauOut),
-		)
-		logShell("SSRF", line)
-		if err := runShellLive(line); err == nil {
-			used = append(used, "gau")
-		} else {
-			logFail("SSRF", "gau ssrf", err)
-		}
-		// gf ssrf (gau_ssrf -> gf_ssrf)
-		if hasTool("gf") && fileExists(gauOut) {
-			lineGF := fmt.Sprintf("cat %s | gf ssrf > %s",
-				escapeShell(gauOut),
-				escapeShell(gfSSRF),
-			)
-			logShell("SSRF", lineGF)
-			if err := runShellLive(lineGF); err == nil {
-				used = append(used, "gf")
-			} else {
-				logFail("SSRF", "gf ssrf", err)
-			}
-		} else if !hasTool("gf") {
-			logMissing("SSRF", "gf")
-		}
-	}
-
-	// httpx filter lagi
-	if hasTool("httpx") && fileExists(gfSSRF) {
-		args := []string{"-l", gfSSRF, "-o", clean, "-mc", "200"}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
-		}
-		logStep("SSRF", "httpx (gf_ssrf->clean)", args)
-		if err := runCommandLive("httpx", args...); err == nil {
-			used = append(used, "httpx")
-		} else {
-			logFail("SSRF", "httpx gf_ssrf", err)
-		}
-	}
-
-	// nuclei diarahkan ke hasil gau (gau_ssrf) atau hosts sebagai fallback.
-	gauSSRF := filepath.Join(tmpDir, "gau_ssrf.txt")
-	list := chooseFirstExisting(gauSSRF, hosts)
-	if list == "" {
-		logInfo("SSRF", "Tidak ada URL kandidat SSRF.")
-		fmt.Println("========== [/MODE SSRF] =========")
-		return unique(used)
-	}
-
-	// nuclei -tags ssrf
-	if hasTool("nuclei") {
-		out := filepath.Join(resultsDir, "nuclei.json")
-		args := []string{"-l", list, "-tags", "ssrf", "-o", out}
-		if speed > 0 {
-			args = append(args, "-c", fmt.Sprintf("%d", speed))
-		}
-		logStep("SSRF", "nuclei", args)
-		if err := runCommandLive("nuclei", args...); err == nil {
-			used = append(used, "nuclei")
-		} else {
-			logFail("SSRF", "nuclei", err)
-		}
-	} else {
-		logMissing("SSRF", "nuclei")
-	}
+// Result is the outcome of RunModes, used for the summary screen, reports
+// and exit codes.
+type Result struct {
+	ID       string             `json:"id"` // run ID, mis. 20261016-202512-3fa2
+	Target   string             `json:"target"`
+	Domain   string             `json:"domain"`
+	Modes    []string           `json:"modes"` // nama mode (xss, sqli, ...)
+	Speed    int                `json:"speed"`
+	Start    time.Time          `json:"start"`
+	End      time.Time          `json:"end"`
+	Tools    []string           `json:"tools"`    // unique tool names that were actually invoked
+	Steps    []StepRecord       `json:"steps"`    // recon + semua step mode, berurutan
+	Findings []findings.Finding `json:"findings"` // temuan terstruktur dari semua mode
+	Aborted  bool               `json:"aborted"`  // run dihentikan user (Ctrl-C dua kali) atau ctx dibatalkan
+
+	ResultsDirs map[string]string `json:"results_dirs"`    // mode -> folder results ("recon" = corpus)
+	Diff        *diff.Run         `json:"diff,omitempty"`  // dibanding run sebelumnya untuk domain yang sama
+	Scope       string            `json:"scope,omitempty"` // file scope yang dipakai
+
+	RateLimit     int `json:"rate_limit,omitempty"`      // req/detik global (0 = tanpa batas)
+	HostRateLimit int `json:"host_rate_limit,omitempty"` // req/detik per host
+
+	Auth  []string `json:"auth,omitempty"`  // nama header auth yang dikirim (tanpa nilai)
+	Proxy string   `json:"proxy,omitempty"` // proxy upstream (kredensial disensor)
+
+	Resolver string `json:"resolver,omitempty"` // DNS server cek native (Options.Resolver)
+
+	Artifacts []Artifact `json:"artifacts,omitempty"` // file antara yang disimpan (--keep-artifacts)
+
+	Workers int           `json:"workers,omitempty"` // budget worker paralel (Options.Workers)
+	Elapsed time.Duration `json:"elapsed,omitempty"` // waktu berjalan (wall clock), dijumlah antar resume
+}
 
-	fmt.Println("========== [/MODE SSRF] =========")
-	return unique(used)
+// RunModes runs all selected modes for the given target.
+// - Modes are expected to be normalized & sorted by the caller.
+// - Workers <= 1: mode & step berurutan; > 1: yang independen paralel.
+// - Recon (subfinder -> httpx -> gau) dijalankan sekali per target.
+// - Setiap step berjalan di bawah ctx dengan timeout per tool (opts).
+// - Selama run, Ctrl-C membatalkan step aktif; Ctrl-C kedua menghentikan run.
+// - Setiap run punya ID; hasil per run, catatan di ~/BUGx/history/<id>.json.
+func RunModes(ctx context.Context, modes []int, target string, opts Options) Result {
+	runCtx, cancelRun := context.WithCancel(ctx)
+	defer cancelRun()
+	intr := newInterrupter(cancelRun)
+	defer intr.Close()
+	return runTarget(runCtx, modes, target, opts, intr, nil)
 }
 
-//
-// OPEN REDIRECT MODE (5)
-//
+// runTarget adalah isi RunModes untuk satu target. intr boleh dibagi
+// beberapa target yang berjalan paralel (RunTargets); pembatalan ctx berarti
+// run dihentikan user. resume (opsional) adalah record run yang dilanjutkan
+// (ResumeRun): run ID, waktu mulai dan work dir-nya dipakai ulang.
+func runTarget(runCtx context.Context, modes []int, target string, opts Options, intr *interrupter, resume *Result) (res Result) {
+	res = Result{
+		Target:        target,
+		Speed:         opts.Speed,
+		Start:         time.Now(),
+		RateLimit:     opts.RateLimit,
+		HostRateLimit: opts.HostRateLimit,
+		Workers:       opts.Workers,
+		Resolver:      opts.Resolver,
+	}
+	started := res.Start
+	var prevElapsed time.Duration
+	defer func() {
+		res.End = time.Now()
+		res.Elapsed = prevElapsed + res.End.Sub(started)
+		if res.ID != "" {
+			saveRunLogged(res)
+		}
+	}()
+
+	if len(modes) == 0 {
+		return res
+	}
 
-func runRedirectChain(target string, speed int) []string {
-	fmt.Println("========== [MODE OPEN REDIRECT] =========")
 	domain := extractDomain(target)
 	if domain == "" {
-		fmt.Printf("[REDIRECT] Target tidak valid: %s\n", target)
-		fmt.Println("========== [/MODE OPEN REDIRECT] =========")
-		return nil
-	}
-
-	tmpDir := buildTempDir(domain, "redirect")
-	defer cleanupTempDir(tmpDir)
-
-	resultsDir := buildModeResultsDir("redirect", domain)
-	_ = os.MkdirAll(resultsDir, 0o755)
-
-	subs := filepath.Join(tmpDir, "subs.txt")
-	hosts := filepath.Join(tmpDir, "hosts.txt")
-	gfRedir := filepath.Join(tmpDir, "gf_redirect.txt")
-	clean := filepath.Join(tmpDir, "clean_redirect.txt")
-
-	var used []string
-
-	// subfinder
-	if hasTool("subfinder") {
-		args := []string{"-d", domain, "-o", subs}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
-		}
-		logStep("REDIRECT", "subfinder", args)
-		if err := runCommandLive("subfinder", args...); err == nil {
-			used = append(used, "subfinder")
-		} else {
-			logFail("REDIRECT", "subfinder", err)
-		}
-	} else {
-		logMissing("REDIRECT", "subfinder")
+		fmt.Printf("[RECON] Target tidak valid: %s\n", target)
+		return res
 	}
-
-	// httpx
-	if hasTool("httpx") && fileExists(subs) {
-		args := []string{"-l", subs, "-o", hosts, "-mc", "200"}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
-		}
-		logStep("REDIRECT", "httpx (subs->hosts)", args)
-		if err := runCommandLive("httpx", args...); err == nil {
-			used = append(used, "httpx")
-		} else {
-			logFail("REDIRECT", "httpx", err)
-		}
-	} else if !hasTool("httpx") {
-		logMissing("REDIRECT", "httpx")
+	res.Domain = domain
+	res.ID = newRunID(res.Start)
+	if resume != nil {
+		res.ID, res.Start = resume.ID, resume.Start
+		prevElapsed, _ = resume.Timing()
 	}
 
-	// gau (hosts -> gau_redirect)
-	if hasTool("gau") && fileExists(hosts) {
-		gauOut := filepath.Join(tmpDir, "gau_redirect.txt")
-		line := fmt.Sprintf("cat %s | gau --threads %d --verbose > %s",
-			escapeShell(hosts),
-			maxInt(speed, 1),
-			escapeShell(gauOut),
-		)
-		logShell("REDIRECT", line)
-		if err := runShellLive(line); err == nil {
-			used = append(used, "gau")
-		} else {
-			logFail("REDIRECT", "gau redirect", err)
-		}
-		// gf redirect (gau_redirect -> gf_redirect)
-		if hasTool("gf") && fileExists(gauOut) {
-			lineGF := fmt.Sprintf("cat %s | gf redirect > %s",
-				escapeShell(gauOut),
-				escapeShell(gfRedir),
-			)
-			logShell("REDIRECT", lineGF)
-			if err := runShellLive(lineGF); err == nil {
-				used = append(used, "gf")
-			} else {
-				logFail("REDIRECT", "gf redirect", err)
-			}
-		} else if !hasTool("gf") {
-			logMissing("REDIRECT", "gf")
-		}
+	opts.ensurePool()
+	setupScope(&opts, domain)
+	if !opts.Scope.Empty() {
+		res.Scope = opts.Scope.Source
 	}
-
-	// httpx filter lagi
-	if hasTool("httpx") && fileExists(gfRedir) {
-		args := []string{"-l", gfRedir, "-o", clean, "-mc", "200"}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
-		}
-		logStep("REDIRECT", "httpx (gf_redirect->clean)", args)
-		if err := runCommandLive("httpx", args...); err == nil {
-			used = append(used, "httpx")
-		} else {
-			logFail("REDIRECT", "httpx gf_redirect", err)
-		}
-	}
-
-	// nuclei diarahkan ke hasil gau (gau_redirect) atau hosts sebagai fallback.
-	gauRedirect := filepath.Join(tmpDir, "gau_redirect.txt")
-	list := chooseFirstExisting(gauRedirect, hosts)
-	if list == "" {
-		logInfo("REDIRECT", "Tidak ada URL kandidat redirect.")
-		fmt.Println("========== [/MODE OPEN REDIRECT] =========")
-		return unique(used)
-	}
-
-	// nuclei -tags redirect
-	if hasTool("nuclei") {
-		out := filepath.Join(resultsDir, "nuclei.json")
-		args := []string{"-l", list, "-tags", "redirect", "-o", out}
-		if speed > 0 {
-			args = append(args, "-c", fmt.Sprintf("%d", speed))
-		}
-		logStep("REDIRECT", "nuclei", args)
-		if err := runCommandLive("nuclei", args...); err == nil {
-			used = append(used, "nuclei")
-		} else {
-			logFail("REDIRECT", "nuclei", err)
-		}
-	} else {
-		logMissing("REDIRECT", "nuclei")
-	}
-
-	fmt.Println("========== [/MODE OPEN REDIRECT] =========")
-	return unique(used)
-}
-
-//
-// SENSITIVE / BACKUP MODE (6)
-//
-
-func runSensitiveChain(target string, speed int) []string {
-	fmt.Println("========== [MODE SENSITIVE/BACKUP] =========")
-	domain := extractDomain(target)
-	if domain == "" {
-		fmt.Printf("[SENSITIVE] Target tidak valid: %s\n", target)
-		fmt.Println("========== [/MODE SENSITIVE/BACKUP] =========")
-		return nil
+	setupAuth(&opts, domain)
+	res.Auth = headerNames(opts.Headers)
+	if opts.Proxy != "" {
+		res.Proxy = redactURLs(opts.Proxy)
+		fmt.Printf("[PROXY] Semua traffic tool lewat %s\n", res.Proxy)
 	}
-
-	tmpDir := buildTempDir(domain, "sensitive")
-	defer cleanupTempDir(tmpDir)
-
-	resultsDir := buildModeResultsDir("sensitive", domain)
-	_ = os.MkdirAll(resultsDir, 0o755)
-
-	subs := filepath.Join(tmpDir, "subs.txt")
-	hosts := filepath.Join(tmpDir, "hosts.txt")
-
-	var used []string
-
-	// subfinder
-	if hasTool("subfinder") {
-		args := []string{"-d", domain, "-o", subs}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
-		}
-		logStep("SENSITIVE", "subfinder", args)
-		if err := runCommandLive("subfinder", args...); err == nil {
-			used = append(used, "subfinder")
-		} else {
-			logFail("SENSITIVE", "subfinder", err)
-		}
-	} else {
-		logMissing("SENSITIVE", "subfinder")
+	if r := opts.describeRate(); r != "" {
+		fmt.Printf("[RATE] Batas request: %s%s\n", r, opts.describeSplit())
 	}
 
-	// httpx (subs -> hosts)
-	if hasTool("httpx") && fileExists(subs) {
-		args := []string{"-l", subs, "-o", hosts, "-mc", "200"}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
+	// Work dir persisten per run: dihapus hanya bila run selesai, supaya run
+	// yang terhenti bisa dilanjutkan (bugx resume <run-id>).
+	workDir := WorkDir(res.ID)
+	_ = os.MkdirAll(workDir, 0o755)
+	defer func() {
+		if !res.Aborted {
+			cleanupTempDir(workDir)
 		}
-		logStep("SENSITIVE", "httpx (subs->hosts)", args)
-		if err := runCommandLive("httpx", args...); err == nil {
-			used = append(used, "httpx")
-		} else {
-			logFail("SENSITIVE", "httpx", err)
-		}
-	} else if !hasTool("httpx") {
-		logMissing("SENSITIVE", "httpx")
-	}
-
-	// Di mode ini kita tidak memaksakan wordlist tertentu.
-	// User bebas pakai ffuf/dirsearch/gobuster/feroxbuster manual.
-	// BUG-X cukup melakukan nuclei exposures/files/backup bila tersedia list host.
-
-	list := chooseFirstExisting(hosts, subs)
-	if list == "" {
-		logInfo("SENSITIVE", "Tidak ada host list untuk scanning exposures.")
-		fmt.Println("========== [/MODE SENSITIVE/BACKUP] =========")
-		return unique(used)
+	}()
+	cp, err := loadCheckpoint(workDir, res.ID)
+	if err != nil {
+		fmt.Printf("[RESUME] [WARN] %v; semua step dijalankan ulang.\n", err)
 	}
 
-	// nuclei exposures/files/backup
-	if hasTool("nuclei") {
-		out := filepath.Join(resultsDir, "nuclei.json")
-		args := []string{
-			"-l", list,
-			"-tags", "exposure,exposures,files,backup",
-			"-o", out,
-		}
-		if speed > 0 {
-			args = append(args, "-c", fmt.Sprintf("%d", speed))
-		}
-		logStep("SENSITIVE", "nuclei", args)
-		if err := runCommandLive("nuclei", args...); err == nil {
-			used = append(used, "nuclei")
-		} else {
-			logFail("SENSITIVE", "nuclei", err)
+	var chains []*Chain
+	needURLs := false
+	for _, m := range modes {
+		c := chainByID(m)
+		if c == nil {
+			fmt.Printf("[INFO] Mode %d belum diimplementasikan.\n", m)
+			continue
 		}
-	} else {
-		logMissing("SENSITIVE", "nuclei")
+		chains = append(chains, c)
+		res.Modes = append(res.Modes, c.Name)
+		needURLs = needURLs || c.NeedsURLs
 	}
 
-	fmt.Println("========== [/MODE SENSITIVE/BACKUP] =========")
-	return unique(used)
-}
+	// Catat run sejak awal: run yang crash tetap terlihat di history
+	// (End kosong = belum selesai).
+	saveRunLogged(res)
 
-//
-// CMS / PANEL MODE (7)
-//
-
-func runCMSChain(target string, speed int) []string {
-	fmt.Println("========== [MODE CMS/PANEL] =========")
-	domain := extractDomain(target)
-	if domain == "" {
-		fmt.Printf("[CMS] Target tidak valid: %s\n", target)
-		fmt.Println("========== [/MODE CMS/PANEL] =========")
-		return nil
-	}
-
-	tmpDir := buildTempDir(domain, "cms")
-	defer cleanupTempDir(tmpDir)
-
-	resultsDir := buildModeResultsDir("cms", domain)
-	_ = os.MkdirAll(resultsDir, 0o755)
-
-	subs := filepath.Join(tmpDir, "subs.txt")
-	hosts := filepath.Join(tmpDir, "hosts.txt")
-
-	var used []string
+	used := make(map[string]struct{})
 
-	// subfinder
-	if hasTool("subfinder") {
-		args := []string{"-d", domain, "-o", subs}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
-		}
-		logStep("CMS", "subfinder", args)
-		if err := runCommandLive("subfinder", args...); err == nil {
-			used = append(used, "subfinder")
-		} else {
-			logFail("CMS", "subfinder", err)
+	reconDir := buildModeResultsDir(reconChain.Name, domain, res.ID)
+	res.ResultsDirs = map[string]string{reconChain.Name: reconDir}
+	rc := runRecon(runCtx, domain, workDir, reconDir, &opts, intr, cp, needURLs)
+	for _, t := range rc.Tools {
+		used[t] = struct{}{}
+	}
+	res.Steps = append(res.Steps, rc.Steps...)
+	res.Artifacts = append(res.Artifacts, rc.Artifacts...)
+
+	// Mode saling independen (hanya bergantung pada corpus recon): dengan
+	// Workers > 1 semua chain berjalan bersamaan dan berbagi worker pool.
+	outs := make([]chainOutcome, len(chains))
+	var wg sync.WaitGroup
+	for i, c := range chains {
+		if runCtx.Err() != nil {
+			break
+		}
+		env := &chainEnv{
+			Chain:      c,
+			Recon:      rc,
+			Opts:       &opts,
+			Intr:       intr,
+			Checkpoint: cp,
+			ResultsDir: buildModeResultsDir(c.Name, domain, res.ID),
+		}
+		res.ResultsDirs[c.Name] = env.ResultsDir
+		if opts.pool.size() <= 1 {
+			outs[i] = runChain(runCtx, c, env)
+			continue
 		}
-	} else {
-		logMissing("CMS", "subfinder")
+		wg.Add(1)
+		go func(i int, c *Chain) {
+			defer wg.Done()
+			outs[i] = runChain(runCtx, c, env)
+		}(i, c)
 	}
-
-	// httpx
-	if hasTool("httpx") && fileExists(subs) {
-		args := []string{
-			"-l", subs,
-			"-o", hosts,
-			"-mc", "200",
-			"-td", // technology detect
-		}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
+	wg.Wait()
+	for _, out := range outs {
+		for _, t := range out.Tools {
+			used[t] = struct{}{}
 		}
-		logStep("CMS", "httpx (subs->hosts tech-detect)", args)
-		if err := runCommandLive("httpx", args...); err == nil {
-			used = append(used, "httpx")
-		} else {
-			logFail("CMS", "httpx", err)
-		}
-	} else if !hasTool("httpx") {
-		logMissing("CMS", "httpx")
-	}
-
-	list := chooseFirstExisting(hosts, subs)
-	if list == "" {
-		logInfo("CMS", "Tidak ada host list untuk scanning CMS.")
-		fmt.Println("========== [/MODE CMS/PANEL] =========")
-		return unique(used)
+		res.Steps = append(res.Steps, out.Steps...)
+		res.Findings = append(res.Findings, out.Findings...)
+		res.Artifacts = append(res.Artifacts, out.Artifacts...)
 	}
+	writeManifests(res.Artifacts)
 
-	// nuclei CMS tags
-	if hasTool("nuclei") {
-		out := filepath.Join(resultsDir, "nuclei.json")
-		args := []string{
-			"-l", list,
-			"-tags", "wp,wordpress,drupal,joomla,cms,login,panel",
-			"-o", out,
-		}
-		if speed > 0 {
-			args = append(args, "-c", fmt.Sprintf("%d", speed))
-		}
-		logStep("CMS", "nuclei", args)
-		if err := runCommandLive("nuclei", args...); err == nil {
-			used = append(used, "nuclei")
-		} else {
-			logFail("CMS", "nuclei", err)
-		}
-	} else {
-		logMissing("CMS", "nuclei")
+	for t := range used {
+		res.Tools = append(res.Tools, t)
 	}
-
-	// wpscan, whatweb, dll tetap dianggap manual; tidak dipaksa otomatis di sini.
-
-	fmt.Println("========== [/MODE CMS/PANEL] =========")
-	return unique(used)
+	sort.Strings(res.Tools)
+	findings.SortBySeverity(res.Findings)
+	res.Aborted = runCtx.Err() != nil
+	res.Diff = compareWithPrevious(&res, rc)
+	return res
 }
 
-//
-// RCE / HIGH IMPACT MODE (8)
-//
-
-func runRCEChain(target string, speed int) []string {
-	fmt.Println("========== [MODE RCE/HIGH IMPACT] =========")
-	domain := extractDomain(target)
-	if domain == "" {
-		fmt.Printf("[RCE] Target tidak valid: %s\n", target)
-		fmt.Println("========== [/MODE RCE/HIGH IMPACT] =========")
-		return nil
-	}
-
-	tmpDir := buildTempDir(domain, "rce")
-	defer cleanupTempDir(tmpDir)
-
-	resultsDir := buildModeResultsDir("rce", domain)
-	_ = os.MkdirAll(resultsDir, 0o755)
-
-	subs := filepath.Join(tmpDir, "subs.txt")
-	hosts := filepath.Join(tmpDir, "hosts.txt")
-
-	var used []string
-
-	// subfinder
-	if hasTool("subfinder") {
-		args := []string{"-d", domain, "-o", subs}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
-		}
-		logStep("RCE", "subfinder", args)
-		if err := runCommandLive("subfinder", args...); err == nil {
-			used = append(used, "subfinder")
-		} else {
-			logFail("RCE", "subfinder", err)
+// chainByID returns the chain definition for a menu mode, or nil.
+func chainByID(id int) *Chain {
+	for _, c := range allChains() {
+		if c.ID == id {
+			return c
 		}
-	} else {
-		logMissing("RCE", "subfinder")
 	}
+	return nil
+}
 
-	// httpx
-	if hasTool("httpx") && fileExists(subs) {
-		args := []string{"-l", subs, "-o", hosts, "-mc", "200"}
-		if speed > 0 {
-			args = append(args, "-t", fmt.Sprintf("%d", speed))
+// isCustom reports whether c was loaded from ~/BUGx/modes.
+func isCustom(c *Chain) bool {
+	for i := range customChains {
+		if &customChains[i] == c {
+			return true
 		}
-		logStep("RCE", "httpx (subs->hosts)", args)
-		if err := runCommandLive("httpx", args...); err == nil {
-			used = append(used, "httpx")
-		} else {
-			logFail("RCE", "httpx", err)
-		}
-	} else if !hasTool("httpx") {
-		logMissing("RCE", "httpx")
 	}
+	return false
+}
 
-	list := chooseFirstExisting(hosts, subs)
-	if list == "" {
-		logInfo("RCE", "Tidak ada host list untuk scanning high-impact.")
-		fmt.Println("========== [/MODE RCE/HIGH IMPACT] =========")
-		return unique(used)
+// allChains returns built-in chains followed by custom chains.
+func allChains() []*Chain {
+	out := make([]*Chain, 0, len(builtinChains)+len(customChains))
+	for i := range builtinChains {
+		out = append(out, &builtinChains[i])
 	}
-
-	// nuclei -tags rce,critical,takeover,ssrf high
-	if hasTool("nuclei") {
-		out := filepath.Join(resultsDir, "nuclei.json")
-		args := []string{
-			"-l", list,
-			"-tags", "rce,critical,takeover",
-			"-o", out,
-		}
-		if speed > 0 {
-			args = append(args, "-c", fmt.Sprintf("%d", speed))
-		}
-		logStep("RCE", "nuclei", args)
-		if err := runCommandLive("nuclei", args...); err == nil {
-			used = append(used, "nuclei")
-		} else {
-			logFail("RCE", "nuclei", err)
-		}
-	} else {
-		logMissing("RCE", "nuclei")
+	for i := range customChains {
+		out = append(out, &customChains[i])
 	}
-
-	fmt.Println("========== [/MODE RCE/HIGH IMPACT] =========")
-	return unique(used)
+	return out
 }
 
 //
@@ -1092,24 +323,6 @@ func hasTool(name string) bool {
 	return err == nil
 }
 
-// runCommandLive executes a command and streams stdout/stderr live.
-func runCommandLive(name string, args ...string) error {
-	fmt.Printf("[CMD] %s %s\n", name, strings.Join(args, " "))
-	cmd := exec.Command(name, args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
-}
-
-// runShellLive runs a shell command (for simple pipe chains) with live output.
-func runShellLive(line string) error {
-	fmt.Printf("[SHELL] %s\n", line)
-	cmd := exec.Command("sh", "-c", line)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
-}
-
 // fileExists checks if a regular file exists.
 func fileExists(path string) bool {
 	if path == "" {
@@ -1132,16 +345,6 @@ func chooseFirstExisting(paths ...string) string {
 	return ""
 }
 
-// buildTempDir constructs a temp directory path for a specific mode.
-func buildTempDir(domain, mode string) string {
-	safeDomain := sanitizeForPath(domain)
-	if mode == "" {
-		mode = "run"
-	}
-	ts := time.Now().UnixNano()
-	return filepath.Join(os.TempDir(), fmt.Sprintf("BUGx-%s-%s-%d", mode, safeDomain, ts))
-}
-
 // cleanupTempDir removes a temp directory and its contents.
 func cleanupTempDir(dir string) {
 	if dir == "" {
@@ -1150,8 +353,8 @@ func cleanupTempDir(dir string) {
 	_ = os.RemoveAll(dir)
 }
 
-// buildModeResultsDir -> ~/BUGx/results/<mode>/<domain>
-func buildModeResultsDir(mode, domain string) string {
+// buildModeResultsDir -> ~/BUGx/results/<mode>/<domain>/<run-id>
+func buildModeResultsDir(mode, domain, runID string) string {
 	if mode == "" {
 		mode = "misc"
 	}
@@ -1167,7 +370,12 @@ func buildModeResultsDir(mode, domain string) string {
 		// Fallback ke relative jika HOME tidak ada
 		base = "."
 	}
-	return filepath.Join(base, "BUGx", "results", strings.ToLower(mode), domain)
+	return filepath.Join(base, "BUGx", "results", strings.ToLower(mode), domain, sanitizeForPath(runID))
+}
+
+// DomainOf returns the bare domain of a target URL or host ("" if invalid).
+func DomainOf(target string) string {
+	return extractDomain(target)
 }
 
 // extractDomain tries to normalize URL or host to bare domain.
@@ -1233,6 +441,14 @@ func maxInt(a, b int) int {
 	return b
 }
 
+// minInt returns min(a,b).
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
 // unique returns a deduplicated copy of tools slice.
 func unique(list []string) []string {
 	if len(list) == 0 {
@@ -1256,11 +472,15 @@ func unique(list []string) []string {
 // Logging helpers to keep output clean & expressive.
 
 func logStep(mode, tool string, args []string) {
-	fmt.Printf("[%s] %s -> %s %s\n", mode, "RUN", tool, strings.Join(args, " "))
+	fmt.Printf("[%s] %s -> %s %s\n", mode, "RUN", tool, strings.Join(redactArgs(args), " "))
 }
 
 func logShell(mode, line string) {
-	fmt.Printf("[%s] SHELL -> %s\n", mode, line)
+	fmt.Printf("[%s] SHELL -> %s\n", mode, redactURLs(line))
+}
+
+func logNative(mode, name, in string) {
+	fmt.Printf("[%s] NATIVE -> %s %s\n", mode, name, in)
 }
 
 func logFail(mode, tool string, err error) {
@@ -1275,6 +495,11 @@ func logInfo(mode, msg string) {
 	fmt.Printf("[%s] [INFO] %s\n", mode, msg)
 }
 
+// BaseDir returns the BUGx data directory (~/BUGx by default).
+func BaseDir() string {
+	return buildBugxBaseDir()
+}
+
 // buildBugxBaseDir returns base directory for BUGx data (~/BUGx by default).
 func buildBugxBaseDir() string {
 	if u, err := user.Current(); err == nil && u.HomeDir != "" {
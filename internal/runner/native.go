@@ -0,0 +1,310 @@
+package runner
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+	"github.com/D0Lv-1N/BUGx/internal/probe"
+	"github.com/D0Lv-1N/BUGx/internal/takeover"
+)
+
+// nativeFunc mengimplementasikan step native (cek bawaan BUGx tanpa binary
+// eksternal). in adalah file input step, out file output yang berisi temuan
+// JSONL (findings.WriteNative); progress ditulis ke w seperti output tool.
+type nativeFunc func(ctx context.Context, env *chainEnv, in, out string, w io.Writer) error
+
+// nativeTimeout adalah batas satu request HTTP cek native.
+const nativeTimeout = 15 * time.Second
+
+// ParseResolver memvalidasi alamat DNS server untuk cek native
+// ("1.1.1.1", "127.0.0.1:5353"); port default 53. Kosong = resolv.conf.
+func ParseResolver(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+	host, port, err := net.SplitHostPort(raw)
+	if err != nil {
+		host, port = strings.Trim(raw, "[]"), "53"
+	}
+	if host == "" || strings.ContainsAny(host, "/ ") {
+		return "", fmt.Errorf("resolver tidak valid: %q (contoh: 1.1.1.1 atau 127.0.0.1:5353)", raw)
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// dnsServer returns Options.Resolver atau nameserver pertama sistem.
+func (o *Options) dnsServer() (string, error) {
+	if o.Resolver != "" {
+		return o.Resolver, nil
+	}
+	return takeover.SystemServer()
+}
+
+// nativeHTTPClient membuat client untuk cek native: lewat Options.Proxy,
+// resolve nama lewat Options.Resolver (bila diisi), TLS tanpa verifikasi
+// (target sering memakai sertifikat layanan pihak ketiga) dan tanpa follow
+// redirect supaya respons asli host yang dinilai.
+func nativeHTTPClient(o *Options) *http.Client {
+	dialer := &net.Dialer{Timeout: nativeTimeout}
+	if o.Resolver != "" {
+		server := o.Resolver
+		dialer.Resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				d := net.Dialer{Timeout: 5 * time.Second}
+				return d.DialContext(ctx, network, server)
+			},
+		}
+	}
+	tr := &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSClientConfig:     &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // scanner
+		TLSHandshakeTimeout: nativeTimeout,
+		MaxIdleConnsPerHost: 2,
+	}
+	if o.Proxy != "" {
+		if u, err := url.Parse(o.Proxy); err == nil {
+			tr.Proxy = http.ProxyURL(u)
+		}
+	}
+	return &http.Client{
+		Transport: tr,
+		Timeout:   nativeTimeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// readHosts membaca list host / URL (subs.txt, hosts.txt) menjadi nama host
+// unik tanpa skema, port, path dan awalan wildcard.
+func readHosts(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var out []string
+	seen := make(map[string]bool)
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		h := strings.TrimPrefix(strings.ToLower(extractDomain(strings.Fields(line)[0])), "*.")
+		if h != "" && !seen[h] {
+			seen[h] = true
+			out = append(out, h)
+		}
+	}
+	return out, sc.Err()
+}
+
+// writeNativeFindings menulis temuan cek native ke out (file tetap dibuat
+// walau kosong, supaya step tercatat menghasilkan output).
+func writeNativeFindings(out string, list []findings.Finding) error {
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	if err := findings.WriteNative(f, list); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// nativeWarnLimit: jumlah error per target yang ditampilkan sebelum
+// diringkas.
+const nativeWarnLimit = 5
+
+// checkFunc memeriksa satu target (host / URL) dan mengembalikan temuannya.
+type checkFunc func(ctx context.Context, target string) ([]findings.Finding, error)
+
+// scanTargets menjalankan check untuk setiap target dengan paralelisme
+// Options.Speed, mencetak setiap temuan ke w lalu menulis semuanya ke out.
+// Error per target hanya diperingatkan (beberapa pertama lalu ringkasan).
+func scanTargets(ctx context.Context, env *chainEnv, targets []string, out string, w *syncWriter, check checkFunc) error {
+	var mu sync.Mutex
+	var list []findings.Finding
+	failed := 0
+	forEach(ctx, targets, env.Opts.Speed, func(target string) {
+		found, err := check(ctx, target)
+		if err != nil && ctx.Err() == nil {
+			mu.Lock()
+			failed++
+			n := failed
+			mu.Unlock()
+			if n <= nativeWarnLimit {
+				w.printf("[WARN] %s: %v\n", target, err)
+			}
+		}
+		for _, f := range found {
+			w.printf("[%s] %s -> %s\n", strings.ToUpper(f.Severity), f.Name, f.URL)
+			if f.Evidence != "" {
+				w.printf("         %s\n", redactSecrets(f.Evidence, env.Opts.Headers))
+			}
+		}
+		mu.Lock()
+		list = append(list, found...)
+		mu.Unlock()
+	})
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if failed > nativeWarnLimit {
+		w.printf("[WARN] %d target gagal dicek (error DNS / jaringan).\n", failed)
+	}
+	w.printf("[%s] %d temuan dari %d target\n", env.Chain.Label, len(list), len(targets))
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].URL != list[j].URL {
+			return list[i].URL < list[j].URL
+		}
+		return list[i].ID < list[j].ID
+	})
+	return writeNativeFindings(out, list)
+}
+
+// prepareRequest returns a hook yang menambahkan header auth Options ke
+// request cek native (Cookie digabung seperti header lain).
+func prepareRequest(o *Options) func(req *http.Request) {
+	return func(req *http.Request) {
+		for _, h := range o.Headers {
+			name, value, ok := strings.Cut(h, ":")
+			if ok {
+				req.Header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+			}
+		}
+	}
+}
+
+// nativeProbe membuat client probe untuk cek native: nativeHTTPClient,
+// header auth Options dan rate limit lim.
+func nativeProbe(o *Options, lim *limiter) *probe.Client {
+	return &probe.Client{
+		HTTP:          nativeHTTPClient(o),
+		Prepare:       prepareRequest(o),
+		BeforeRequest: lim.wait,
+	}
+}
+
+// readURLs membaca list URL / host menjadi URL unik; entri tanpa skema
+// dianggap https://.
+func readURLs(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var out []string
+	seen := make(map[string]bool)
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		u := strings.Fields(line)[0]
+		if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+			u = "https://" + strings.TrimPrefix(u, "*.")
+		}
+		if !seen[u] {
+			seen[u] = true
+			out = append(out, u)
+		}
+	}
+	return out, sc.Err()
+}
+
+// forEach menjalankan fn untuk setiap item dengan paling banyak workers
+// goroutine. Item sisa dilewati bila ctx dibatalkan.
+func forEach(ctx context.Context, items []string, workers int, fn func(item string)) {
+	workers = maxInt(minInt(workers, len(items)), 1)
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for it := range jobs {
+				fn(it)
+			}
+		}()
+	}
+feed:
+	for _, it := range items {
+		select {
+		case jobs <- it:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// limiter membatasi request cek native ke rps per detik (nil = tanpa batas).
+type limiter struct {
+	tick *time.Ticker
+}
+
+// newLimiter mengembalikan nil (tanpa batas) untuk rps <= 0 dan untuk rps
+// yang terlalu besar sampai jedanya bulat jadi 0 — NewTicker panic di 0.
+func newLimiter(rps int) *limiter {
+	if rps <= 0 {
+		return nil
+	}
+	interval := time.Second / time.Duration(rps)
+	if interval <= 0 {
+		return nil
+	}
+	return &limiter{tick: time.NewTicker(interval)}
+}
+
+// wait menunggu giliran request berikutnya.
+func (l *limiter) wait(ctx context.Context) error {
+	if l == nil {
+		return ctx.Err()
+	}
+	select {
+	case <-l.tick.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *limiter) stop() {
+	if l != nil {
+		l.tick.Stop()
+	}
+}
+
+// syncWriter menyerialkan tulisan progress dari banyak goroutine.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) printf(format string, args ...any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fmt.Fprintf(s.w, format, args...)
+}
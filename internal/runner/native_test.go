@@ -0,0 +1,31 @@
+package runner
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestNewLimiter(t *testing.T) {
+	tests := []struct {
+		rps   int
+		limit bool
+	}{
+		{0, false},
+		{-1, false},
+		{1, true},
+		{MaxRate, true},
+		{2e9, false}, // jeda bulat jadi 0: tanpa batas, bukan panic
+		{math.MaxInt, false},
+	}
+	for _, tt := range tests {
+		l := newLimiter(tt.rps)
+		if (l != nil) != tt.limit {
+			t.Errorf("newLimiter(%d) = %v, want limit %v", tt.rps, l, tt.limit)
+		}
+		if err := l.wait(context.Background()); err != nil {
+			t.Errorf("newLimiter(%d).wait = %v", tt.rps, err)
+		}
+		l.stop()
+	}
+}
@@ -0,0 +1,116 @@
+package runner
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/D0Lv-1N/BUGx/internal/scope"
+)
+
+// MaxRate adalah batas atas --rate / --host-rate. Di atas ini jeda antar
+// request sudah di bawah resolusi yang berarti; nilai lebih besar ditolak CLI.
+const MaxRate = 100000
+
+// Options mengatur perilaku satu run (dipakai menu maupun CLI).
+type Options struct {
+	Speed int
+
+	// StepTimeout adalah batas waktu setiap step (--timeout). 0 = pakai
+	// default bawaan per tool (defaultToolTimeouts), selain itu tanpa batas.
+	StepTimeout time.Duration
+	// ToolTimeouts meng-override StepTimeout per nama tool (--tool-timeout:
+	// gau, nuclei, ...). Hanya berisi nilai dari user.
+	ToolTimeouts map[string]time.Duration
+
+	// Scope (opsional) memfilter setiap list target antar step. nil = pakai
+	// ~/BUGx/scope/<domain>.{txt,csv,json} bila ada.
+	Scope *scope.Scope
+
+	// RateLimit adalah batas request per detik seluruh run dan HostRateLimit
+	// batas per host (0 = tanpa batas). Diterjemahkan ke flag tiap tool oleh
+	// withRateFlags (nuclei/httpx -rl, dalfox --delay, ...).
+	RateLimit     int
+	HostRateLimit int
+
+	// Headers ("Nama: nilai", termasuk Cookie) dikirim ke setiap tool yang
+	// dikenal authFlags. Kosong = pakai ~/BUGx/auth/<domain>.txt bila ada.
+	Headers []string
+
+	// Proxy (opsional) adalah proxy upstream untuk semua traffic HTTP tool,
+	// mis. http://127.0.0.1:8080 (Burp / ZAP). Lihat proxyFlags.
+	Proxy string
+
+	// Resolver (opsional) adalah DNS server host:port untuk cek native
+	// (takeover). Kosong = nameserver pertama /etc/resolv.conf.
+	Resolver string
+
+	// KeepArtifacts menyalin file antara (gf_*, clean_*, ...) ke folder
+	// results run dan menulis manifest.json (step penghasil + jumlah baris).
+	KeepArtifacts bool
+
+	// Workers adalah budget worker global: jumlah maksimal step (proses
+	// tool) yang berjalan bersamaan di seluruh run, dan jumlah target yang
+	// discan bersamaan oleh RunTargets. <= 1 = semua berurutan. Mode dan step
+	// yang tidak saling bergantung berjalan paralel dengan output berprefix.
+	Workers int
+
+	pool        *workerPool // dibuat sekali per run dari Workers (ensurePool)
+	multiTarget bool        // prefix output memuat domain (RunTargets)
+}
+
+// ensurePool membuat worker pool bila belum ada, supaya semua target dan
+// mode dalam satu run berbagi budget yang sama.
+func (o *Options) ensurePool() {
+	if o.pool == nil {
+		o.pool = newWorkerPool(o.Workers)
+	}
+}
+
+// defaultToolTimeouts: tool yang sering hang pada target besar / source
+// lambat. Hanya dipakai bila user tidak mengisi --timeout / --tool-timeout.
+var defaultToolTimeouts = map[string]time.Duration{
+	"subfinder": 20 * time.Minute,
+	"gau":       30 * time.Minute,
+	"gf":        5 * time.Minute,
+}
+
+// DefaultOptions returns options with the given speed. Timeout bawaan per
+// tool diterapkan oleh timeoutFor, bukan disalin ke ToolTimeouts.
+func DefaultOptions(speed int) Options {
+	return Options{Speed: speed}
+}
+
+// timeoutFor returns the effective timeout for tool (0 = tanpa batas):
+// --tool-timeout, lalu --timeout, lalu default bawaan tool.
+func (o *Options) timeoutFor(tool string) time.Duration {
+	if d, ok := o.ToolTimeouts[tool]; ok {
+		return d
+	}
+	if o.StepTimeout > 0 {
+		return o.StepTimeout
+	}
+	return defaultToolTimeouts[tool]
+}
+
+// ParseToolTimeouts parses "nuclei=2h,gau=30m" into a per-tool map.
+// Nilai 0 berarti tanpa batas untuk tool tersebut.
+func ParseToolTimeouts(raw string) (map[string]time.Duration, error) {
+	out := make(map[string]time.Duration)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		tool, val, ok := strings.Cut(part, "=")
+		if !ok || strings.TrimSpace(tool) == "" {
+			return nil, fmt.Errorf("format timeout tidak valid: %q (contoh: nuclei=2h)", part)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(val))
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("durasi tidak valid untuk %s: %q", tool, val)
+		}
+		out[strings.ToLower(strings.TrimSpace(tool))] = d
+	}
+	return out, nil
+}
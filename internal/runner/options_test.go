@@ -0,0 +1,39 @@
+package runner
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeoutFor(t *testing.T) {
+	tests := []struct {
+		name  string
+		step  time.Duration
+		tools string
+		tool  string
+		want  time.Duration
+	}{
+		{"default bawaan", 0, "", "gau", 30 * time.Minute},
+		{"tanpa default bawaan", 0, "", "nuclei", 0},
+		{"--timeout mengalahkan default bawaan", 5 * time.Minute, "", "gau", 5 * time.Minute},
+		{"--timeout lebih besar dari default", 2 * time.Hour, "", "subfinder", 2 * time.Hour},
+		{"--timeout untuk tool lain", 5 * time.Minute, "", "nuclei", 5 * time.Minute},
+		{"--tool-timeout mengalahkan --timeout", 5 * time.Minute, "gau=1h", "gau", time.Hour},
+		{"--tool-timeout 0 = tanpa batas", 5 * time.Minute, "gau=0", "gau", 0},
+		{"--tool-timeout tool lain", 5 * time.Minute, "nuclei=3h", "gf", 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions(1)
+			opts.StepTimeout = tt.step
+			tools, err := ParseToolTimeouts(tt.tools)
+			if err != nil {
+				t.Fatal(err)
+			}
+			opts.ToolTimeouts = tools
+			if got := opts.timeoutFor(tt.tool); got != tt.want {
+				t.Errorf("timeoutFor(%s) = %v, want %v", tt.tool, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+//go:build windows
+
+package runner
+
+import "os/exec"
+
+// Windows tidak punya process group ala POSIX; cukup matikan proses utama.
+
+func setProcessGroup(cmd *exec.Cmd) {}
+
+func terminateProcessGroup(cmd *exec.Cmd) {
+	killProcessGroup(cmd)
+}
+
+func killProcessGroup(cmd *exec.Cmd) {
+	if cmd.Process == nil {
+		return
+	}
+	_ = cmd.Process.Kill()
+}
@@ -0,0 +1,102 @@
+package runner
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// rateFlags menerjemahkan batas request Options (req/detik) ke flag
+// masing-masing tool. Hanya tool yang mengirim request ke target yang
+// dipetakan; subfinder/gau memakai sumber pasif dan gf berjalan lokal.
+// Flag ditambahkan di belakang Args, kecuali step sudah memakainya sendiri.
+var rateFlags = map[string]func(o *Options) []string{
+	// httpx, nuclei, katana: -rl = maksimal request per detik (global per proses).
+	"httpx":  func(o *Options) []string { return rpsFlag("-rl", o.effectiveRate()) },
+	"nuclei": func(o *Options) []string { return rpsFlag("-rl", o.effectiveRate()) },
+	"katana": func(o *Options) []string { return rpsFlag("-rl", o.effectiveRate()) },
+	"ffuf":   func(o *Options) []string { return rpsFlag("-rate", o.effectiveRate()) },
+	// dalfox hanya punya --delay (ms antar request ke target yang sama) per
+	// worker; -w {speed} worker paralel -> delay = speed * 1000 / rps.
+	"dalfox": func(o *Options) []string {
+		rps := o.effectiveRate()
+		if rps <= 0 {
+			return nil
+		}
+		workers := maxInt(o.Speed, 1)
+		delay := (workers*1000 + rps - 1) / rps
+		return []string{"--delay", strconv.Itoa(delay)}
+	},
+}
+
+// rateFlagNames: nama flag per tool, untuk mendeteksi step yang sudah
+// mengatur rate limit sendiri (mis. mode custom).
+var rateFlagNames = map[string][]string{
+	"httpx":  {"-rl", "-rate-limit", "-rlm", "-rate-limit-minute"},
+	"nuclei": {"-rl", "-rate-limit", "-rlm", "-rate-limit-minute"},
+	"katana": {"-rl", "-rate-limit", "-rlm", "-rate-limit-minute"},
+	"ffuf":   {"-rate", "-p"},
+	"dalfox": {"--delay"},
+}
+
+// effectiveRate adalah batas req/detik yang dipakai tool tanpa limit per
+// host: nilai terkecil dari RateLimit dan HostRateLimit yang diisi. Lebih
+// lambat dari yang diizinkan untuk banyak host, tapi tidak pernah melanggar
+// batas per host program. Dengan Workers > 1 batas dibagi rata ke setiap
+// proses tool yang bisa berjalan bersamaan, supaya totalnya tetap di bawah.
+func (o *Options) effectiveRate() int {
+	rps := o.RateLimit
+	if rps <= 0 || (o.HostRateLimit > 0 && o.HostRateLimit < rps) {
+		rps = o.HostRateLimit
+	}
+	if rps <= 0 {
+		return 0
+	}
+	return maxInt(rps/maxInt(o.Workers, 1), 1)
+}
+
+// withRateFlags menambahkan flag rate limit tool ke args (bila ada batas
+// dan args belum mengaturnya).
+func withRateFlags(tool string, args []string, o *Options) []string {
+	fn, ok := rateFlags[tool]
+	if !ok || o.effectiveRate() <= 0 {
+		return args
+	}
+	for _, a := range args {
+		for _, f := range rateFlagNames[tool] {
+			if a == f || a == "-"+f || strings.HasPrefix(a, f+"=") {
+				return args
+			}
+		}
+	}
+	return append(args, fn(o)...)
+}
+
+// describeRate -> "10 req/s global, 2 req/s per host" (untuk log).
+func (o *Options) describeRate() string {
+	switch {
+	case o.RateLimit > 0 && o.HostRateLimit > 0:
+		return fmt.Sprintf("%d req/s global, %d req/s per host", o.RateLimit, o.HostRateLimit)
+	case o.RateLimit > 0:
+		return fmt.Sprintf("%d req/s global", o.RateLimit)
+	case o.HostRateLimit > 0:
+		return fmt.Sprintf("%d req/s per host", o.HostRateLimit)
+	}
+	return ""
+}
+
+// describeSplit -> ", 5 req/s per tool (10 worker paralel)" bila batas
+// dibagi ke beberapa worker, "" bila tidak.
+func (o *Options) describeSplit() string {
+	if o.Workers <= 1 || o.effectiveRate() <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(", %d req/s per tool (%d worker paralel)", o.effectiveRate(), o.Workers)
+}
+
+func rpsFlag(flag string, rps int) []string {
+	if rps <= 0 {
+		return nil
+	}
+	return []string{flag, strconv.Itoa(rps)}
+}
@@ -0,0 +1,60 @@
+package runner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEffectiveRate(t *testing.T) {
+	tests := []struct {
+		name                  string
+		rate, hostRate, works int
+		want                  int
+	}{
+		{"tanpa batas", 0, 0, 0, 0},
+		{"global saja", 10, 0, 0, 10},
+		{"per host saja", 0, 3, 0, 3},
+		{"per host lebih kecil", 10, 3, 0, 3},
+		{"global lebih kecil", 2, 5, 0, 2},
+		{"dibagi ke worker", 10, 0, 4, 2},
+		{"minimal 1 per worker", 3, 0, 8, 1},
+		{"worker 1", 10, 0, 1, 10},
+	}
+	for _, tt := range tests {
+		o := &Options{RateLimit: tt.rate, HostRateLimit: tt.hostRate, Workers: tt.works}
+		if got := o.effectiveRate(); got != tt.want {
+			t.Errorf("%s: effectiveRate() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWithRateFlags(t *testing.T) {
+	tests := []struct {
+		name  string
+		tool  string
+		args  []string
+		rate  int
+		speed int
+		want  []string
+	}{
+		{"tanpa batas", "nuclei", []string{"-l", "x"}, 0, 1, []string{"-l", "x"}},
+		{"nuclei -rl", "nuclei", []string{"-l", "x"}, 10, 1, []string{"-l", "x", "-rl", "10"}},
+		{"httpx -rl", "httpx", nil, 5, 1, []string{"-rl", "5"}},
+		{"ffuf -rate", "ffuf", []string{"-u", "x"}, 7, 1, []string{"-u", "x", "-rate", "7"}},
+		{"dalfox delay", "dalfox", []string{"file", "x"}, 10, 4, []string{"file", "x", "--delay", "400"}},
+		{"dalfox delay dibulatkan ke atas", "dalfox", nil, 3, 1, []string{"--delay", "334"}},
+		{"tool pasif", "subfinder", []string{"-d", "x"}, 10, 1, []string{"-d", "x"}},
+		{"tool tidak dikenal", "sqlmap", nil, 10, 1, nil},
+		{"step sudah pakai -rl", "nuclei", []string{"-rl", "50"}, 10, 1, []string{"-rl", "50"}},
+		{"step sudah pakai --rate-limit", "nuclei", []string{"--rate-limit", "50"}, 10, 1, []string{"--rate-limit", "50"}},
+		{"step sudah pakai -rate=", "ffuf", []string{"-rate=9"}, 10, 1, []string{"-rate=9"}},
+		{"step sudah pakai --delay", "dalfox", []string{"--delay", "1"}, 10, 1, []string{"--delay", "1"}},
+	}
+	for _, tt := range tests {
+		o := &Options{RateLimit: tt.rate, Speed: tt.speed}
+		got := withRateFlags(tt.tool, tt.args, o)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: withRateFlags = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
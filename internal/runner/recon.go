@@ -0,0 +1,56 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+)
+
+// reconResult menyimpan corpus recon bersama untuk satu target.
+// Dibuat sekali oleh runRecon lalu dipakai oleh semua mode terpilih,
+// sehingga RUN ALL tidak lagi menjalankan subfinder/httpx/gau berulang kali.
+type reconResult struct {
+	Domain string
+	Dir    string // work dir run; mode menaruh gf_*/clean_* di sini juga
+	Corpus string // folder results recon per run (subs/hosts/gau disimpan untuk diff)
+	Subs   string // subs.txt  (subfinder)
+	Hosts  string // hosts.txt (httpx -mc 200)
+	URLs   string // gau.txt   (gau, hanya bila ada mode yang butuh URL)
+	Tools  []string
+	Steps  []StepRecord
+
+	Artifacts []Artifact // output recon (--keep-artifacts)
+}
+
+// runRecon menjalankan reconChain sekali per target. Step yang gagal / tool
+// yang tidak ada hanya dilaporkan; mode tetap berjalan dengan file apa pun
+// yang berhasil dibuat. Corpus ditulis ke corpusDir (folder results recon)
+// supaya run berikutnya bisa membandingkan aset baru.
+func runRecon(ctx context.Context, domain, dir, corpusDir string, opts *Options, intr *interrupter, cp *checkpoint, withURLs bool) *reconResult {
+	if corpusDir == "" {
+		corpusDir = dir
+	}
+	_ = os.MkdirAll(corpusDir, 0o755)
+	rc := &reconResult{
+		Domain: domain,
+		Dir:    dir,
+		Corpus: corpusDir,
+		Subs:   filepath.Join(corpusDir, "subs.txt"),
+		Hosts:  filepath.Join(corpusDir, "hosts.txt"),
+		URLs:   filepath.Join(corpusDir, "gau.txt"),
+	}
+
+	env := &chainEnv{
+		Chain:      &reconChain,
+		Recon:      rc,
+		Opts:       opts,
+		Intr:       intr,
+		Checkpoint: cp,
+		NeedURLs:   withURLs,
+	}
+	out := runChain(ctx, &reconChain, env)
+	rc.Tools = out.Tools
+	rc.Steps = out.Steps
+	rc.Artifacts = out.Artifacts
+	return rc
+}
@@ -0,0 +1,71 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRunReconSharedCorpus: recon berjalan sekali, gau hanya bila ada mode
+// yang butuh URL, dan setiap mode membaca corpus yang sama.
+func TestRunReconSharedCorpus(t *testing.T) {
+	calls := filepath.Join(t.TempDir(), "calls")
+	fakeTools(t, map[string]string{
+		"subfinder": `echo subfinder >> ` + calls + `; printf 'a.example.com\nb.example.com\n' > "$4"`,
+		"httpx":     `echo httpx >> ` + calls + `; sed 's|^|https://|' "$2" > "$4"`,
+		"gau":       `echo gau >> ` + calls + `; sed 's|$|/?q=1|'`,
+		"modetool":  `echo modetool >> ` + calls + `; cp "$2" "$4"`,
+	})
+
+	tests := []struct {
+		name     string
+		withURLs bool
+		wantURLs bool
+	}{
+		{"tanpa mode URL", false, false},
+		{"dengan mode URL", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Remove(calls)
+			work, corpus := t.TempDir(), t.TempDir()
+			opts := &Options{}
+			rc := runRecon(context.Background(), "example.com", work, corpus, opts, nil, nil, tt.withURLs)
+
+			if rc.Subs != filepath.Join(corpus, "subs.txt") || rc.Hosts != filepath.Join(corpus, "hosts.txt") {
+				t.Errorf("corpus = %s, %s; want di %s", rc.Subs, rc.Hosts, corpus)
+			}
+			hosts, _ := os.ReadFile(rc.Hosts)
+			if string(hosts) != "https://a.example.com\nhttps://b.example.com\n" {
+				t.Errorf("hosts.txt = %q", hosts)
+			}
+			if fileExists(rc.URLs) != tt.wantURLs {
+				t.Errorf("gau.txt ada = %v, want %v", fileExists(rc.URLs), tt.wantURLs)
+			}
+
+			// Dua mode memakai corpus recon yang sama tanpa recon ulang.
+			for _, name := range []string{"xss", "sqli"} {
+				c := &Chain{Name: name, Label: name, Title: name, Steps: []Step{
+					{Name: "modetool", Tool: "modetool", Args: []string{"-l", "{in}", "-o", "{out}"},
+						Inputs: []string{"{hosts}"}, Output: "{work}/" + name + ".txt"},
+				}}
+				out := runChain(context.Background(), c, &chainEnv{Chain: c, Recon: rc, Opts: opts})
+				if len(out.Steps) != 1 || out.Steps[0].Status != StepOK {
+					t.Errorf("%s steps = %+v", name, out.Steps)
+				}
+			}
+
+			got, _ := os.ReadFile(calls)
+			want := "subfinder\nhttpx\n"
+			if tt.withURLs {
+				want += "gau\n"
+			}
+			want += "modetool\nmodetool\n"
+			if string(got) != want {
+				t.Errorf("urutan tool = %q, want %q", strings.Fields(string(got)), strings.Fields(want))
+			}
+		})
+	}
+}
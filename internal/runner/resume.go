@@ -0,0 +1,165 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/D0Lv-1N/BUGx/internal/scope"
+)
+
+// checkpointFile adalah nama file checkpoint di work dir run.
+const checkpointFile = "checkpoint.json"
+
+// WorkDir -> ~/BUGx/work/<run-id>: file antara run (gf_*, clean_*) dan
+// checkpoint. Dihapus setelah run selesai; run yang terhenti menyimpannya
+// untuk `bugx resume`.
+func WorkDir(runID string) string {
+	return filepath.Join(buildBugxBaseDir(), "work", sanitizeForPath(runID))
+}
+
+// checkpoint mencatat step yang sudah selesai beserta artefaknya. Ditulis
+// ulang setelah setiap step sehingga crash / Ctrl-C kehilangan paling banyak
+// satu step.
+type checkpoint struct {
+	mu   sync.Mutex
+	path string
+
+	RunID string           `json:"run_id"`
+	Steps []checkpointStep `json:"steps"`
+}
+
+// checkpointStep adalah satu step selesai (Status ok) dan file output-nya.
+type checkpointStep struct {
+	Record StepRecord `json:"record"`
+	Output string     `json:"output,omitempty"`
+}
+
+// loadCheckpoint membaca checkpoint di dir; file yang belum ada menghasilkan
+// checkpoint kosong.
+func loadCheckpoint(dir, runID string) (*checkpoint, error) {
+	cp := &checkpoint{path: filepath.Join(dir, checkpointFile), RunID: runID}
+	data, err := os.ReadFile(cp.path)
+	if os.IsNotExist(err) {
+		return cp, nil
+	}
+	if err != nil {
+		return cp, err
+	}
+	if err := json.Unmarshal(data, cp); err != nil {
+		return &checkpoint{path: cp.path, RunID: runID}, fmt.Errorf("%s: %w", checkpointFile, err)
+	}
+	return cp, nil
+}
+
+// done returns the checkpoint entry of a finished step whose output still
+// exists (step tanpa output cukup tercatat).
+func (cp *checkpoint) done(mode, step string) (checkpointStep, bool) {
+	if cp == nil {
+		return checkpointStep{}, false
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	for _, s := range cp.Steps {
+		if s.Record.Mode != mode || s.Record.Name != step {
+			continue
+		}
+		if s.Output != "" && !fileExists(s.Output) {
+			return checkpointStep{}, false
+		}
+		return s, true
+	}
+	return checkpointStep{}, false
+}
+
+// record mencatat step yang selesai lalu menulis checkpoint (atomik).
+func (cp *checkpoint) record(rec StepRecord, out string) {
+	if cp == nil || rec.Status != StepOK {
+		return
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.Steps = append(cp.Steps, checkpointStep{Record: rec, Output: out})
+
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err == nil {
+		tmp := cp.path + ".tmp"
+		if err = os.WriteFile(tmp, data, 0o644); err == nil {
+			err = os.Rename(tmp, cp.path)
+		}
+	}
+	if err != nil {
+		fmt.Printf("[RESUME] [WARN] Checkpoint tidak bisa ditulis: %v\n", err)
+	}
+}
+
+// ResumeRun melanjutkan run yang terhenti (Ctrl-C, crash, mesin mati) dengan
+// run ID, folder results dan work dir yang sama. Step yang tercatat selesai
+// di checkpoint dilewati; temuannya dibaca ulang dari file output.
+// Speed, mode, workers, rate limit, resolver dan scope diambil dari
+// history; header auth dan proxy berkredensial tidak disimpan sehingga harus
+// diisi lagi lewat opts (atau ~/BUGx/auth/<domain>.txt).
+func ResumeRun(ctx context.Context, id string, opts Options) (Result, error) {
+	prev, err := LoadRun(id)
+	if err != nil {
+		return Result{}, err
+	}
+	if prev.Status() == RunDone {
+		return Result{}, fmt.Errorf("run %s sudah selesai, tidak ada yang perlu dilanjutkan", id)
+	}
+
+	var modes []int
+	for _, name := range prev.Modes {
+		m, ok := ModeByName(name)
+		if !ok {
+			return Result{}, fmt.Errorf("mode %q dari run %s tidak terdaftar lagi", name, id)
+		}
+		modes = append(modes, m)
+	}
+
+	if opts.Speed <= 0 {
+		opts.Speed = prev.Speed
+	}
+	if opts.Resolver == "" {
+		opts.Resolver = prev.Resolver
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = prev.Workers
+	}
+	if opts.RateLimit == 0 {
+		opts.RateLimit = prev.RateLimit
+	}
+	if opts.HostRateLimit == 0 {
+		opts.HostRateLimit = prev.HostRateLimit
+	}
+	if opts.Scope == nil && prev.Scope != "" {
+		if opts.Scope, err = scope.Load(prev.Scope); err != nil {
+			return Result{}, fmt.Errorf("scope run %s: %w", id, err)
+		}
+	}
+	if opts.Proxy == "" && prev.Proxy != "" {
+		if strings.Contains(prev.Proxy, "***") {
+			return Result{}, fmt.Errorf("run %s memakai proxy berkredensial; isi lagi --proxy", id)
+		}
+		opts.Proxy = prev.Proxy
+	}
+	if len(opts.Headers) == 0 && len(prev.Auth) > 0 {
+		fmt.Printf("[RESUME] [WARN] Run %s memakai header %s; nilainya tidak disimpan (isi -H/--cookie/--auth atau ~/BUGx/auth).\n",
+			id, strings.Join(prev.Auth, ", "))
+	}
+
+	if !fileExists(filepath.Join(WorkDir(id), checkpointFile)) {
+		fmt.Printf("[RESUME] [WARN] Checkpoint run %s tidak ada; semua step dijalankan ulang.\n", id)
+	}
+	fmt.Printf("[RESUME] Melanjutkan run %s (%s, %s)\n", id, prev.Target, strings.Join(prev.Modes, ","))
+
+	runCtx, cancelRun := context.WithCancel(ctx)
+	defer cancelRun()
+	intr := newInterrupter(cancelRun)
+	defer intr.Close()
+	return runTarget(runCtx, modes, prev.Target, opts, intr, &prev), nil
+}
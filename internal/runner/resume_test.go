@@ -0,0 +1,102 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestResumeFromCheckpoint: step yang tercatat selesai di checkpoint tidak
+// dijalankan ulang dan temuannya dibaca ulang dari output; step yang gagal
+// atau output-nya hilang dijalankan lagi.
+func TestResumeFromCheckpoint(t *testing.T) {
+	calls := filepath.Join(t.TempDir(), "calls")
+	nuclei := `echo nuclei >> ` + calls + `; echo '{"template-id":"xss-reflected","info":{"severity":"high"},"matched-at":"https://a.example.com/?q=1"}' > "$2"`
+	fakeTools(t, map[string]string{
+		"nuclei": nuclei,
+		// flaky gagal sampai file ok ada.
+		"flaky": `echo flaky >> ` + calls + `; test -f "$(dirname "$2")/ok" && touch "$2"`,
+	})
+
+	work := t.TempDir()
+	c := &Chain{Name: "xss", Label: "XSS", Title: "XSS", Steps: []Step{
+		{Name: "nuclei xss", Tool: "nuclei", Args: []string{"-o", "{out}"}, Output: "{work}/nuclei.json"},
+		{Name: "flaky", Tool: "flaky", Args: []string{"-o", "{out}"}, Output: "{work}/flaky.txt"},
+	}}
+	run := func() chainOutcome {
+		t.Helper()
+		os.Remove(calls)
+		cp, err := loadCheckpoint(work, "run-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		env := &chainEnv{
+			Chain:      c,
+			Recon:      &reconResult{Domain: "example.com", Dir: work},
+			Opts:       &Options{},
+			Checkpoint: cp,
+		}
+		return runChain(context.Background(), c, env)
+	}
+	statuses := func(out chainOutcome) string {
+		var s []string
+		for _, r := range out.Steps {
+			s = append(s, r.Status)
+		}
+		return strings.Join(s, ",")
+	}
+	called := func() string {
+		data, _ := os.ReadFile(calls)
+		return strings.Join(strings.Fields(string(data)), ",")
+	}
+
+	// Run pertama: flaky gagal, hanya nuclei yang masuk checkpoint.
+	out := run()
+	if statuses(out) != "ok,failed" || len(out.Findings) != 1 {
+		t.Fatalf("run 1: status %s, %d temuan", statuses(out), len(out.Findings))
+	}
+
+	// Resume: nuclei dilewati tapi temuannya tetap ada, flaky dijalankan ulang.
+	if err := os.WriteFile(filepath.Join(work, "ok"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out = run()
+	if statuses(out) != "ok,ok" || called() != "flaky" {
+		t.Fatalf("resume: status %s, tool dipanggil %q; want ok,ok dan hanya flaky", statuses(out), called())
+	}
+	if len(out.Findings) != 1 || out.Findings[0].ID != "xss-reflected" || out.Findings[0].Mode != "xss" {
+		t.Errorf("resume: findings = %+v", out.Findings)
+	}
+
+	// Semua step selesai: resume berikutnya tidak menjalankan apa pun.
+	if out = run(); called() != "" {
+		t.Errorf("resume kedua menjalankan %q", called())
+	}
+
+	// Output step yang hilang membuat step dijalankan ulang.
+	os.Remove(filepath.Join(work, "nuclei.json"))
+	if out = run(); called() != "nuclei" || len(out.Findings) != 1 {
+		t.Errorf("output hilang: tool dipanggil %q, %d temuan", called(), len(out.Findings))
+	}
+}
+
+func TestLoadCheckpointCorrupt(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, checkpointFile), []byte("{rusak"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cp, err := loadCheckpoint(dir, "run-1")
+	if err == nil {
+		t.Fatal("checkpoint rusak tidak dilaporkan")
+	}
+	if cp == nil || len(cp.Steps) != 0 || cp.RunID != "run-1" {
+		t.Errorf("checkpoint = %+v; want kosong supaya semua step dijalankan ulang", cp)
+	}
+	// Checkpoint kosong tetap bisa ditulis ulang.
+	cp.record(StepRecord{Mode: "xss", Name: "a", Status: StepOK}, "")
+	if cp, err = loadCheckpoint(dir, "run-1"); err != nil || len(cp.Steps) != 1 {
+		t.Errorf("setelah record: %+v, %v", cp, err)
+	}
+}
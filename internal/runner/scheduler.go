@@ -0,0 +1,148 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"sync"
+)
+
+// workerPool membatasi jumlah proses tool yang berjalan bersamaan di seluruh
+// run (semua target dan mode berbagi satu pool, Options.Workers).
+type workerPool struct {
+	slots chan struct{}
+}
+
+func newWorkerPool(n int) *workerPool {
+	if n < 1 {
+		n = 1
+	}
+	return &workerPool{slots: make(chan struct{}, n)}
+}
+
+// size returns the worker budget (1 = berurutan).
+func (p *workerPool) size() int {
+	if p == nil {
+		return 1
+	}
+	return cap(p.slots)
+}
+
+// acquire menunggu slot kosong. Return false bila ctx dibatalkan lebih dulu;
+// bila true, release wajib dipanggil.
+func (p *workerPool) acquire(ctx context.Context) bool {
+	if p == nil {
+		return ctx.Err() == nil
+	}
+	select {
+	case p.slots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (p *workerPool) release() {
+	if p != nil {
+		<-p.slots
+	}
+}
+
+// stepDeps returns, per step, the earlier steps it waits for: step yang
+// Output-nya menjadi salah satu Inputs step ini. Step tanpa Inputs (mis.
+// Shell custom yang membaca file sendiri) menunggu semua step sebelumnya,
+// sama seperti eksekusi berurutan.
+func stepDeps(c *Chain) [][]int {
+	deps := make([][]int, len(c.Steps))
+	for i, st := range c.Steps {
+		if len(st.Inputs) == 0 {
+			for j := 0; j < i; j++ {
+				deps[i] = append(deps[i], j)
+			}
+			continue
+		}
+		for j := 0; j < i; j++ {
+			for _, in := range st.Inputs {
+				if c.Steps[j].Output != "" && c.Steps[j].Output == in {
+					deps[i] = append(deps[i], j)
+					break
+				}
+			}
+		}
+	}
+	return deps
+}
+
+// outputMu menjaga agar baris output dari tool yang berjalan paralel tidak
+// tercampur di tengah baris.
+var outputMu sync.Mutex
+
+// prefixWriter menulis output tool per baris ke stdout dengan prefix mode,
+// mis. "[XSS] ...". Dipakai bila Options.Workers > 1.
+type prefixWriter struct {
+	prefix []byte
+	buf    []byte
+}
+
+func newPrefixWriter(prefix string) *prefixWriter {
+	return &prefixWriter{prefix: []byte("[" + prefix + "] ")}
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.buf = append(p.buf, b...)
+	for {
+		i := bytes.IndexAny(p.buf, "\n\r")
+		if i < 0 {
+			break
+		}
+		if i > 0 {
+			p.emit(p.buf[:i])
+		}
+		p.buf = p.buf[i+1:]
+	}
+	return len(b), nil
+}
+
+// Flush menulis sisa output yang belum diakhiri newline.
+func (p *prefixWriter) Flush() {
+	if len(p.buf) > 0 {
+		p.emit(p.buf)
+		p.buf = nil
+	}
+}
+
+func (p *prefixWriter) emit(line []byte) {
+	outputMu.Lock()
+	defer outputMu.Unlock()
+	out := make([]byte, 0, len(p.prefix)+len(line)+1)
+	out = append(out, p.prefix...)
+	out = append(out, line...)
+	out = append(out, '\n')
+	os.Stdout.Write(out)
+}
+
+// stepOutput returns the stdout/stderr writers for one step: terminal
+// langsung saat berurutan, prefixWriter saat paralel, masing-masing di-tee ke
+// log step (bila ada). flush dipanggil setelah proses selesai.
+func stepOutput(env *chainEnv, log *stepLog) (stdout, stderr io.Writer, flush func()) {
+	stdout, stderr, flush = os.Stdout, os.Stderr, func() {}
+	if env.Opts.pool.size() > 1 {
+		prefix := env.Chain.Label
+		if env.Opts.multiTarget && env.Recon != nil {
+			prefix = env.Recon.Domain + " " + prefix
+		}
+		w := newPrefixWriter(prefix)
+		stdout, stderr, flush = w, w, w.Flush
+	}
+	if log == nil {
+		return stdout, stderr, flush
+	}
+	if stdout == stderr {
+		// Writer yang sama untuk keduanya: exec hanya memakai satu goroutine
+		// penyalin, sehingga prefixWriter tidak ditulis bersamaan.
+		w := io.MultiWriter(stdout, log)
+		return w, w, flush
+	}
+	return io.MultiWriter(stdout, log), io.MultiWriter(stderr, log), flush
+}
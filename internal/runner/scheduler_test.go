@@ -0,0 +1,92 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStepDeps(t *testing.T) {
+	step := func(out string, in ...string) Step { return Step{Output: out, Inputs: in} }
+	tests := []struct {
+		name  string
+		steps []Step
+		want  [][]int
+	}{
+		{"satu step", []Step{step("a")}, [][]int{nil}},
+		{"rantai", []Step{step("a"), step("b", "a"), step("c", "b")}, [][]int{nil, {0}, {1}}},
+		{"cabang independen", []Step{step("a", "seed"), step("b", "seed"), step("c", "a")}, [][]int{nil, nil, {0}}},
+		{"fallback input", []Step{step("gf"), step("clean", "gf"), step("n", "clean", "gf")}, [][]int{nil, {0}, {0, 1}}},
+		{"tanpa Inputs menunggu semua", []Step{step("a", "seed"), step("b", "seed"), step("")}, [][]int{nil, nil, {0, 1}}},
+		{"output kosong bukan dependensi", []Step{step(""), step("b", "")}, [][]int{nil, nil}},
+		{"hanya step sebelumnya", []Step{step("a", "b"), step("b")}, [][]int{nil, {0}}},
+	}
+	for _, tt := range tests {
+		if got := stepDeps(&Chain{Steps: tt.steps}); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: stepDeps = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// Recon bawaan: httpx menunggu subfinder, gau menunggu httpx.
+	if got := stepDeps(&reconChain); !reflect.DeepEqual(got, [][]int{nil, {0}, {1}}) {
+		t.Errorf("stepDeps(recon) = %v", got)
+	}
+}
+
+// TestRunChainParallel: step independen berjalan bersamaan tapi tidak lebih
+// dari Workers, step yang membaca output step lain menunggu step itu, dan
+// record tetap berurutan sesuai chain.
+func TestRunChainParallel(t *testing.T) {
+	work := t.TempDir()
+	trace := filepath.Join(work, "trace")
+	fakeTools(t, map[string]string{
+		// $2 = input, $4 = output; output baru ada setelah step selesai.
+		"slow": `test -f "$2" || exit 1; echo + >> ` + trace + `; sleep 0.2; echo - >> ` + trace + `; touch "$4"`,
+	})
+	if err := os.WriteFile(filepath.Join(work, "seed.txt"), []byte("x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	st := func(name, in string) Step {
+		return Step{Name: name, Tool: "slow", Args: []string{"-l", "{in}", "-o", "{out}"},
+			Inputs: []string{in}, Output: "{work}/" + name + ".txt"}
+	}
+	c := &Chain{Name: "x", Label: "X", Title: "X", Steps: []Step{
+		st("a", "{work}/seed.txt"),
+		st("b", "{work}/seed.txt"),
+		st("c", "{work}/a.txt"),
+		st("d", "{work}/seed.txt"),
+	}}
+	opts := &Options{Workers: 2}
+	opts.ensurePool()
+	env := &chainEnv{Chain: c, Recon: &reconResult{Domain: "example.com", Dir: work}, Opts: opts}
+	out := runChain(context.Background(), c, env)
+
+	var names []string
+	for _, r := range out.Steps {
+		names = append(names, r.Name+"="+r.Status)
+	}
+	if got := strings.Join(names, ","); got != "a=ok,b=ok,c=ok,d=ok" {
+		t.Errorf("steps = %s", got)
+	}
+
+	data, err := os.ReadFile(trace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	running, peak := 0, 0
+	for _, ev := range strings.Fields(string(data)) {
+		if ev == "+" {
+			running++
+		} else {
+			running--
+		}
+		peak = maxInt(peak, running)
+	}
+	if peak != 2 {
+		t.Errorf("maksimal %d step bersamaan, want 2 (Workers)", peak)
+	}
+}
@@ -0,0 +1,129 @@
+package runner
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/D0Lv-1N/BUGx/internal/scope"
+)
+
+// scopeSampleLimit adalah jumlah entri terbuang yang ditampilkan di log.
+const scopeSampleLimit = 3
+
+// ScopeDir -> ~/BUGx/scope (file scope default per domain).
+func ScopeDir() string {
+	return filepath.Join(buildBugxBaseDir(), "scope")
+}
+
+// DefaultScope loads ~/BUGx/scope/<domain>.{txt,csv,json} (nil bila tidak ada).
+func DefaultScope(domain string) (*scope.Scope, error) {
+	for _, ext := range []string{".txt", ".csv", ".json"} {
+		path := filepath.Join(ScopeDir(), sanitizeForPath(domain)+ext)
+		if fileExists(path) {
+			return scope.Load(path)
+		}
+	}
+	return nil, nil
+}
+
+// setupScope memilih scope run ini dan memperingatkan bila target sendiri
+// berada di luar scope.
+func setupScope(opts *Options, domain string) {
+	if opts.Scope == nil {
+		sc, err := DefaultScope(domain)
+		if err != nil {
+			fmt.Printf("[SCOPE] [WARN] Scope default tidak bisa dibaca: %v\n", err)
+			return
+		}
+		opts.Scope = sc
+	}
+	if opts.Scope == nil {
+		return
+	}
+	for _, w := range opts.Scope.Warnings {
+		fmt.Printf("[SCOPE] [WARN] %s: %s\n", filepath.Base(opts.Scope.Source), w)
+	}
+	if opts.Scope.Empty() {
+		return
+	}
+	fmt.Printf("[SCOPE] Memakai %s (%d in-scope, %d out-of-scope)\n",
+		opts.Scope.Source, len(opts.Scope.Includes), len(opts.Scope.Excludes))
+	if ok, reason := opts.Scope.Check(domain); !ok {
+		fmt.Printf("[SCOPE] [WARN] Target %s sendiri di luar scope (%s); hanya aset in-scope yang discan.\n", domain, reason)
+	}
+}
+
+// applyScope memfilter file list target in-place (hanya step dengan
+// TargetList; output tool lain tidak pernah ditulis ulang). Entri adalah kolom pertama
+// setiap baris (httpx -td menambahkan kolom teknologi). Entri yang dibuang
+// dicatat di log dan di scope_dropped.txt folder corpus recon.
+func applyScope(env *chainEnv, st *Step, path string) int {
+	sc := env.Opts.Scope
+	if sc.Empty() || !st.TargetList || !fileExists(path) {
+		return 0
+	}
+	label := env.Chain.Label
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		logFail(label, "scope "+filepath.Base(path), err)
+		return 0
+	}
+
+	var kept strings.Builder
+	var dropped []string
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if ok, reason := sc.Check(fields[0]); !ok {
+			dropped = append(dropped, fields[0]+"\t"+reason)
+			continue
+		}
+		kept.WriteString(line)
+		kept.WriteByte('\n')
+	}
+	if len(dropped) == 0 {
+		return 0
+	}
+	if err := os.WriteFile(path, []byte(kept.String()), 0o644); err != nil {
+		logFail(label, "scope "+filepath.Base(path), err)
+		return 0
+	}
+
+	var sample []string
+	for i, d := range dropped {
+		if i == scopeSampleLimit {
+			sample = append(sample, "...")
+			break
+		}
+		entry, _, _ := strings.Cut(d, "\t")
+		sample = append(sample, entry)
+	}
+	logInfo(label, fmt.Sprintf("[SCOPE] %s: %d entri di luar scope dibuang (%s)",
+		filepath.Base(path), len(dropped), strings.Join(sample, ", ")))
+	writeDropped(env, st, dropped)
+	return len(dropped)
+}
+
+// writeDropped menambahkan entri terbuang ke <corpus>/scope_dropped.txt
+// (format: step<TAB>entri<TAB>alasan).
+func writeDropped(env *chainEnv, st *Step, dropped []string) {
+	if env.Recon == nil || env.Recon.Corpus == "" {
+		return
+	}
+	f, err := os.OpenFile(filepath.Join(env.Recon.Corpus, "scope_dropped.txt"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	for _, d := range dropped {
+		fmt.Fprintf(w, "%s %s\t%s\n", env.Chain.Name, st.Name, d)
+	}
+	w.Flush()
+}
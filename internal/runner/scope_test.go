@@ -0,0 +1,65 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/D0Lv-1N/BUGx/internal/scope"
+)
+
+func TestApplyScope(t *testing.T) {
+	sc, err := scope.Parse(strings.NewReader("*.example.com\n!admin.example.com\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	env := &chainEnv{
+		Chain: &Chain{Name: "xss", Label: "XSS"},
+		Opts:  &Options{Scope: sc},
+		Recon: &reconResult{Corpus: dir},
+	}
+	hosts := filepath.Join(dir, "hosts.txt")
+	input := "https://www.example.com [nginx]\n" +
+		"https://admin.example.com\n" +
+		"\n" +
+		"https://evil.com/x\n" +
+		"api.example.com\n"
+	if err := os.WriteFile(hosts, []byte(input), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Step yang bukan list target tidak pernah ditulis ulang.
+	if n := applyScope(env, &Step{Name: "nuclei"}, hosts); n != 0 {
+		t.Fatalf("applyScope tanpa TargetList = %d, want 0", n)
+	}
+
+	st := &Step{Name: "httpx", TargetList: true}
+	if n := applyScope(env, st, hosts); n != 2 {
+		t.Fatalf("applyScope = %d, want 2", n)
+	}
+	data, err := os.ReadFile(hosts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "https://www.example.com [nginx]\napi.example.com\n"; string(data) != want {
+		t.Errorf("hosts.txt = %q, want %q", data, want)
+	}
+
+	dropped, err := os.ReadFile(filepath.Join(dir, "scope_dropped.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(dropped)), "\n")
+	if len(lines) != 2 ||
+		!strings.HasPrefix(lines[0], "xss httpx\thttps://admin.example.com\texclude admin.example.com") ||
+		!strings.HasPrefix(lines[1], "xss httpx\thttps://evil.com/x\t") {
+		t.Errorf("scope_dropped.txt:\n%s", dropped)
+	}
+
+	// Semua sudah in-scope: file tidak disentuh, scope_dropped.txt tidak bertambah.
+	if n := applyScope(env, st, hosts); n != 0 {
+		t.Errorf("applyScope kedua = %d, want 0", n)
+	}
+}
@@ -0,0 +1,77 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+	"github.com/D0Lv-1N/BUGx/internal/ssti"
+)
+
+// sstiMaxURLs membatasi kandidat URL berparameter yang diuji per run.
+const sstiMaxURLs = 500
+
+// sstiStep: probe aritmetika polyglot + per engine ke parameter kandidat
+// gf ssti (unik lewat qsreplace, atau hasil httpx bila qsreplace tidak ada).
+func sstiStep() Step {
+	return Step{
+		Name:   "ssti (template probes)",
+		Tool:   "ssti",
+		Run:    runSSTI,
+		Inputs: []string{"{work}/qs_ssti.txt", "{work}/clean_ssti.txt"},
+		Output: "{results}/ssti.json",
+	}
+}
+
+// runSSTI adalah nativeFunc mode SSTI.
+func runSSTI(ctx context.Context, env *chainEnv, in, out string, w io.Writer) error {
+	all, err := readURLs(in)
+	if err != nil {
+		return err
+	}
+	var targets []string
+	for _, u := range all {
+		if strings.Contains(u, "?") {
+			targets = append(targets, u)
+		}
+	}
+	targets = targets[:minInt(len(targets), sstiMaxURLs)]
+
+	sw := &syncWriter{w: w}
+	lim := newLimiter(env.Opts.effectiveRate())
+	defer lim.stop()
+	chk := &ssti.Checker{Client: nativeProbe(env.Opts, lim)}
+	sw.printf("[SSTI] %d URL berparameter, %d probe deteksi + %d probe fingerprint\n", len(targets), len(ssti.Detect), len(ssti.Fingerprint))
+
+	return scanTargets(ctx, env, targets, out, sw, func(ctx context.Context, target string) ([]findings.Finding, error) {
+		issues, err := chk.Check(ctx, target)
+		var list []findings.Finding
+		for _, is := range issues {
+			list = append(list, sstiFinding(is))
+		}
+		return list, err
+	})
+}
+
+func sstiFinding(is ssti.Issue) findings.Finding {
+	var engines []string
+	for _, e := range is.Engines {
+		engines = append(engines, ssti.EngineName(e))
+	}
+	return findings.Finding{
+		Tool:     "ssti",
+		ID:       "ssti-" + is.Engine,
+		Name:     is.Title,
+		Severity: is.Severity,
+		URL:      is.URL,
+		Param:    is.Param,
+		Evidence: fmt.Sprintf("payload %s di %s -> %q muncul di respons (cocok: %s)",
+			is.Payload, is.Param, is.Matched, strings.Join(engines, ", ")),
+		Request:   fmt.Sprintf("GET %s HTTP/1.1\r\nHost: %s\r\n", requestURI(is.URL), hostOf(is.URL)),
+		Curl:      fmt.Sprintf("curl -sk %s", escapeShell(is.URL)),
+		Timestamp: time.Now(),
+	}
+}
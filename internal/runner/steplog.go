@@ -0,0 +1,101 @@
+package runner
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// stepLogDir adalah subfolder results mode tempat log step ditulis.
+const stepLogDir = "logs"
+
+// stepLog adalah file log satu step: command line, output stdout/stderr tool
+// (di-tee selama step berjalan) lalu status, exit code dan durasi. Log tetap
+// ada setelah layar dibersihkan menu, dan ikut tersimpan di folder results.
+// Output ditulis per baris supaya nilai header auth bisa disensor.
+type stepLog struct {
+	mu      sync.Mutex
+	f       *os.File
+	buf     []byte
+	headers []string
+}
+
+// openStepLog membuat <results>/logs/<step>.log (recon: folder corpus) dan
+// menulis header. Gagal membuat log hanya diperingatkan; step tetap jalan.
+func openStepLog(env *chainEnv, st *Step, rec *StepRecord) *stepLog {
+	dir := env.ResultsDir
+	if dir == "" && env.Recon != nil {
+		dir = env.Recon.Corpus
+	}
+	if dir == "" {
+		return nil
+	}
+	dir = filepath.Join(dir, stepLogDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		logFail(env.Chain.Label, "log step", err)
+		return nil
+	}
+	path := filepath.Join(dir, stepLogName(st.Name))
+	f, err := os.Create(path)
+	if err != nil {
+		logFail(env.Chain.Label, "log step", err)
+		return nil
+	}
+	l := &stepLog{f: f, headers: env.Opts.Headers}
+	fmt.Fprintf(l, "# mode      : %s\n", rec.Mode)
+	fmt.Fprintf(l, "# step      : %s\n", rec.Name)
+	fmt.Fprintf(l, "# command   : %s\n", rec.Command)
+	fmt.Fprintf(l, "# start     : %s\n", rec.Start.Format(time.RFC3339))
+	rec.Log = path
+	return l
+}
+
+func (l *stepLog) Write(b []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.buf = append(l.buf, b...)
+	i := bytes.LastIndexByte(l.buf, '\n')
+	if i < 0 {
+		return len(b), nil
+	}
+	_, err := l.f.WriteString(redactSecrets(string(l.buf[:i+1]), l.headers))
+	l.buf = l.buf[i+1:]
+	return len(b), err
+}
+
+// finish menulis status akhir step lalu menutup file.
+func (l *stepLog) finish(rec *StepRecord) {
+	if l == nil {
+		return
+	}
+	if len(l.buf) > 0 {
+		l.Write([]byte("\n"))
+	}
+	fmt.Fprintf(l, "# status    : %s\n", rec.Status)
+	if rec.Error != "" {
+		fmt.Fprintf(l, "# error     : %s\n", rec.Error)
+	}
+	fmt.Fprintf(l, "# exit code : %d\n", rec.ExitCode)
+	fmt.Fprintf(l, "# duration  : %s\n", rec.Duration.Round(time.Millisecond))
+	_ = l.f.Close()
+}
+
+// stepLogName -> "httpx__gf_xss-_clean.log" (aman untuk nama file).
+func stepLogName(step string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		}
+		return '_'
+	}, strings.TrimSpace(step))
+	name = strings.Trim(name, "_")
+	if name == "" {
+		name = "step"
+	}
+	return name + ".log"
+}
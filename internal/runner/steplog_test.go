@@ -0,0 +1,81 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestStepLog: setiap step menulis log berisi command, output tool dan
+// status akhir; nilai header auth disensor, juga bila terpotong antar write.
+func TestStepLog(t *testing.T) {
+	fakeTools(t, map[string]string{
+		"nuclei": `echo "args: $*" >&2; sleep 0.1; printf '> Authorization: Bearer sek'; sleep 0.1; printf 'ret123\n'; printf 'tanpa newline'; exit 3`,
+	})
+	results := t.TempDir()
+	c := &Chain{Name: "xss", Label: "XSS", Title: "XSS", Steps: []Step{
+		{Name: "nuclei (clean->xss)", Tool: "nuclei", Args: []string{"-o", "{out}"}, Output: "{work}/n.json"},
+	}}
+	env := &chainEnv{
+		Chain:      c,
+		Recon:      &reconResult{Domain: "example.com", Dir: t.TempDir()},
+		Opts:       &Options{Headers: []string{"Authorization: Bearer sekret123"}},
+		ResultsDir: results,
+	}
+	out := runChain(context.Background(), c, env)
+	if len(out.Steps) != 1 {
+		t.Fatalf("steps = %+v", out.Steps)
+	}
+	rec := out.Steps[0]
+	if rec.Status != StepFailed || rec.ExitCode != 3 {
+		t.Errorf("status = %s, exit = %d", rec.Status, rec.ExitCode)
+	}
+	wantPath := filepath.Join(results, stepLogDir, "nuclei__clean-_xss.log")
+	if rec.Log != wantPath {
+		t.Errorf("Log = %q, want %q", rec.Log, wantPath)
+	}
+	if strings.Contains(rec.Command, "sekret123") {
+		t.Errorf("Command tidak disensor: %s", rec.Command)
+	}
+
+	data, err := os.ReadFile(wantPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := string(data)
+	if strings.Contains(log, "sekret123") {
+		t.Errorf("log berisi nilai header:\n%s", log)
+	}
+	for _, want := range []string{
+		"# mode      : xss\n",
+		"# step      : nuclei (clean->xss)\n",
+		"# command   : nuclei -o ",
+		"-H Authorization: ***",
+		"> Authorization: ***\n",
+		"tanpa newline\n",
+		"# status    : failed\n",
+		"# exit code : 3\n",
+		"# duration  : ",
+	} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log tidak berisi %q:\n%s", want, log)
+		}
+	}
+}
+
+func TestStepLogName(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"subfinder", "subfinder.log"},
+		{"httpx (gf_xss->clean)", "httpx__gf_xss-_clean.log"},
+		{"cat | grep / x", "cat___grep___x.log"},
+		{"  ", "step.log"},
+		{"../../etc", ".._.._etc.log"},
+	}
+	for _, tt := range tests {
+		if got := stepLogName(tt.in); got != tt.want {
+			t.Errorf("stepLogName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+	"github.com/D0Lv-1N/BUGx/internal/takeover"
+)
+
+// takeoverStep: resolve CNAME semua subdomain recon lalu cocokkan dengan
+// tabel fingerprint layanan (takeover.Fingerprints + ~/BUGx/takeover.json).
+func takeoverStep() Step {
+	return Step{
+		Name:   "takeover (cname)",
+		Tool:   "takeover",
+		Run:    runTakeover,
+		Inputs: []string{"{subs}", "{hosts}"},
+		Output: "{results}/takeover.json",
+	}
+}
+
+// TakeoverFingerprintsFile -> ~/BUGx/takeover.json: fingerprint tambahan
+// (JSON array {service, cname, body, nxdomain}); service yang sama
+// menggantikan entri bawaan.
+func TakeoverFingerprintsFile() string {
+	return filepath.Join(buildBugxBaseDir(), "takeover.json")
+}
+
+// takeoverFingerprints returns the built-in table merged with the local file.
+func takeoverFingerprints(w *syncWriter) []takeover.Fingerprint {
+	extra, err := takeover.LoadFingerprints(TakeoverFingerprintsFile())
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return takeover.Fingerprints
+	case err != nil:
+		w.printf("[WARN] Fingerprint tambahan dilewati: %v\n", err)
+		return takeover.Fingerprints
+	}
+	return takeover.Merge(takeover.Fingerprints, extra)
+}
+
+// runTakeover adalah nativeFunc mode takeover.
+func runTakeover(ctx context.Context, env *chainEnv, in, out string, w io.Writer) error {
+	hosts, err := readHosts(in)
+	if err != nil {
+		return err
+	}
+	server, err := env.Opts.dnsServer()
+	if err != nil {
+		return fmt.Errorf("resolver DNS: %w (isi --resolver)", err)
+	}
+	sw := &syncWriter{w: w}
+	lim := newLimiter(env.Opts.effectiveRate())
+	defer lim.stop()
+	// Halaman error milik layanan pihak ketiga: header auth target tidak
+	// ikut dikirim.
+	client := nativeProbe(env.Opts, lim)
+	client.Prepare = nil
+	client.MaxBody = takeover.MaxBody
+	chk := &takeover.Checker{
+		DNS:          &takeover.Resolver{Server: server},
+		Client:       client,
+		Fingerprints: takeoverFingerprints(sw),
+	}
+	sw.printf("[TAKEOVER] %d host, DNS %s, %d fingerprint layanan\n", len(hosts), server, len(chk.Fingerprints))
+
+	return scanTargets(ctx, env, hosts, out, sw, func(ctx context.Context, host string) ([]findings.Finding, error) {
+		cand, err := chk.Check(ctx, host)
+		if err != nil || cand == nil {
+			return nil, err
+		}
+		return []findings.Finding{takeoverFinding(*cand)}, nil
+	})
+}
+
+// takeoverFinding: kandidat terkonfirmasi (NXDOMAIN di layanan yang bisa
+// didaftarkan ulang / halaman error layanan) = high; dangling CNAME lain =
+// medium, perlu dicek manual.
+func takeoverFinding(c takeover.Candidate) findings.Finding {
+	f := findings.Finding{
+		Tool:      "takeover",
+		ID:        "takeover-dangling-cname",
+		Name:      "Dangling CNAME",
+		Severity:  findings.SevMedium,
+		URL:       c.URL,
+		Evidence:  "CNAME " + strings.Join(append([]string{c.Host}, c.Chain...), " -> ") + "; " + c.Reason,
+		Timestamp: time.Now(),
+	}
+	if f.URL == "" {
+		f.URL = "http://" + c.Host + "/"
+	} else {
+		f.Curl = "curl -sk " + escapeShell(c.URL)
+	}
+	if c.Service != "" {
+		f.ID = "takeover-" + strings.ToLower(strings.NewReplacer(" ", "-", ".", "-").Replace(c.Service))
+		f.Name = "Subdomain takeover (" + c.Service + ")"
+	}
+	if c.Confirm {
+		f.Severity = findings.SevHigh
+	}
+	return f
+}
@@ -0,0 +1,161 @@
+package runner
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ExpandTargets mengubah input target (menu atau -t) menjadi daftar target.
+// raw boleh berisi beberapa target dipisah koma / spasi; entri "@file" atau
+// path file .txt yang ada dibaca sebagai daftar target (satu per baris).
+// Wildcard root seperti "*.example.com" menjadi "example.com" (subfinder
+// mencari subdomainnya). Target dengan domain yang sama hanya diambil sekali.
+func ExpandTargets(raw string) ([]string, error) {
+	var out []string
+	seen := make(map[string]struct{})
+	add := func(t string) {
+		t = targetRoot(t)
+		d := extractDomain(t)
+		if d == "" {
+			return
+		}
+		if _, ok := seen[d]; ok {
+			return
+		}
+		seen[d] = struct{}{}
+		out = append(out, t)
+	}
+
+	for _, item := range strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	}) {
+		path, isFile := strings.CutPrefix(item, "@")
+		if !isFile {
+			isFile = strings.EqualFold(filepath.Ext(item), ".txt") && fileExists(item)
+		}
+		if !isFile {
+			add(item)
+			continue
+		}
+		list, err := LoadTargets(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range list {
+			add(t)
+		}
+	}
+	return out, nil
+}
+
+// LoadTargets membaca file target: satu domain / URL / wildcard root per
+// baris, # untuk komentar, kolom tambahan diabaikan.
+func LoadTargets(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("file target: %w", err)
+	}
+	defer f.Close()
+
+	var out []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		out = append(out, strings.Fields(line)[0])
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("file target %s: %w", path, err)
+	}
+	return out, nil
+}
+
+// targetRoot: "*.example.com" -> "example.com", "https://*.example.com" ->
+// "https://example.com". Wildcard di dalam label ("api-*.example.com",
+// "*-dev.example.com") membuang seluruh label tersebut.
+func targetRoot(t string) string {
+	scheme := ""
+	if i := strings.Index(t, "://"); i >= 0 {
+		scheme, t = t[:i+3], t[i+3:]
+	}
+	if i := strings.LastIndex(t, "*"); i >= 0 {
+		t = t[i+1:]
+		if j := strings.Index(t, "."); j >= 0 {
+			t = t[j+1:]
+		} else {
+			t = ""
+		}
+	}
+	return scheme + t
+}
+
+// RunTargets menjalankan mode terpilih untuk setiap target. Paling banyak
+// opts.Workers target berjalan bersamaan; setiap target punya run ID,
+// folder results dan record history sendiri. Ctrl-C berlaku untuk semua
+// target yang sedang berjalan; Ctrl-C kedua menghentikan seluruh batch
+// (target yang belum mulai tidak dijalankan dan tidak ada di hasil).
+// Semua target berbagi budget Options.Workers (lihat effectiveRate).
+// Hasil berurutan sesuai targets.
+func RunTargets(ctx context.Context, modes []int, targets []string, opts Options) []Result {
+	if len(targets) == 1 {
+		return []Result{RunModes(ctx, modes, targets[0], opts)}
+	}
+
+	batchCtx, cancelBatch := context.WithCancel(ctx)
+	defer cancelBatch()
+	intr := newInterrupter(cancelBatch)
+	defer intr.Close()
+
+	workers := opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(targets) {
+		workers = len(targets)
+	}
+	fmt.Printf("[TARGET] %d target, %d berjalan bersamaan\n", len(targets), workers)
+	// Semua target berbagi satu worker pool, jadi total proses tool (dan
+	// rate limit yang dibagi per worker) tetap dalam budget Workers.
+	opts.ensurePool()
+	opts.multiTarget = true
+
+	results := make([]Result, len(targets))
+	ran := make([]bool, len(targets))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		sem <- struct{}{}
+		if batchCtx.Err() != nil {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(i int, t string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fmt.Printf("[TARGET %d/%d] %s\n", i+1, len(targets), t)
+			res := runTarget(batchCtx, modes, t, opts, intr, nil)
+			fmt.Printf("[TARGET %d/%d] %s selesai (run %s, %d temuan)\n", i+1, len(targets), t, res.ID, len(res.Findings))
+			results[i], ran[i] = res, true
+		}(i, t)
+	}
+	wg.Wait()
+
+	var out []Result
+	for i := range results {
+		if ran[i] {
+			out = append(out, results[i])
+		}
+	}
+	if skipped := len(targets) - len(out); skipped > 0 {
+		fmt.Printf("[TARGET] %d target tidak dijalankan (run dihentikan).\n", skipped)
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTargetRoot(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"example.com", "example.com"},
+		{"*.example.com", "example.com"},
+		{"https://*.example.com", "https://example.com"},
+		{"api-*.example.com", "example.com"},
+		{"*-dev.staging.example.com", "staging.example.com"},
+		{"*.*.example.com", "example.com"},
+		{"*", ""},
+		{"https://a.example.com/x", "https://a.example.com/x"},
+	}
+	for _, tt := range tests {
+		if got := targetRoot(tt.in); got != tt.want {
+			t.Errorf("targetRoot(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExpandTargets(t *testing.T) {
+	dir := t.TempDir()
+	list := filepath.Join(dir, "targets.txt")
+	data := "# program A\n" +
+		"a.example.com   in-scope\n" +
+		"\n" +
+		"*.example.org\n" +
+		"https://example.com\n"
+	if err := os.WriteFile(list, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		raw  string
+		want []string
+	}{
+		{"satu target", "example.com", []string{"example.com"}},
+		{"koma dan spasi", "example.com, example.org\texample.net", []string{"example.com", "example.org", "example.net"}},
+		{"wildcard jadi root", "*.example.com", []string{"example.com"}},
+		{"dedupe per domain", "example.com,https://example.com,*.example.com", []string{"example.com"}},
+		{"wildcard di file", "@" + list, []string{"a.example.com", "example.org", "https://example.com"}},
+		{"path .txt tanpa @", list, []string{"a.example.com", "example.org", "https://example.com"}},
+		{"file + target lain, dedupe", "example.org,@" + list, []string{"example.org", "a.example.com", "https://example.com"}},
+		{"target tidak valid dibuang", "*,example.com", []string{"example.com"}},
+		{"kosong", " , ", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandTargets(tt.raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExpandTargets(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+
+	_, err := ExpandTargets("@" + filepath.Join(dir, "tidak-ada.txt"))
+	if err == nil || !strings.Contains(err.Error(), "file target") {
+		t.Errorf("file hilang: err = %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package scope
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// assetTypes adalah tipe aset HackerOne / Bugcrowd yang relevan untuk
+// BUGx. Aset lain (aplikasi mobile, source code, hardware) dilewati.
+var assetTypes = map[string]bool{
+	"":           true,
+	"url":        true,
+	"wildcard":   true,
+	"domain":     true,
+	"cidr":       true,
+	"ip_address": true,
+	"website":    true,
+	"api":        true,
+	"iprange":    true,
+}
+
+// parseHackerOneCSV membaca export scope HackerOne
+// (identifier, asset_type, ..., eligible_for_submission, ...).
+// eligible_for_submission=false menjadi pengecualian.
+func parseHackerOneCSV(r io.Reader) (*Scope, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	col := make(map[string]int)
+	for i, h := range header {
+		col[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	idCol, ok := col["identifier"]
+	if !ok {
+		return nil, fmt.Errorf("kolom identifier tidak ada (bukan export scope HackerOne?)")
+	}
+	get := func(rec []string, name string) string {
+		if i, ok := col[name]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+
+	s := &Scope{}
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if idCol >= len(rec) {
+			continue
+		}
+		typ := strings.ToLower(get(rec, "asset_type"))
+		if !assetTypes[typ] {
+			continue
+		}
+		exclude := strings.EqualFold(get(rec, "eligible_for_submission"), "false")
+		addIdentifiers(s, rec[idCol], exclude)
+	}
+	return s, nil
+}
+
+// targetsFile adalah format JSON bounty-targets-data / API program:
+// {"targets": {"in_scope": [...], "out_of_scope": [...]}}. Tiap target
+// memakai salah satu field identifier yang umum dipakai platform.
+type targetsFile struct {
+	Targets struct {
+		InScope    []targetEntry `json:"in_scope"`
+		OutOfScope []targetEntry `json:"out_of_scope"`
+	} `json:"targets"`
+}
+
+type targetEntry struct {
+	Target          string `json:"target"`           // Bugcrowd
+	AssetIdentifier string `json:"asset_identifier"` // HackerOne
+	Endpoint        string `json:"endpoint"`         // Intigriti
+	Type            string `json:"type"`
+	AssetType       string `json:"asset_type"`
+}
+
+func (e targetEntry) identifier() string {
+	for _, v := range []string{e.AssetIdentifier, e.Target, e.Endpoint} {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func parseTargetsJSON(r io.Reader) (*Scope, error) {
+	var tf targetsFile
+	if err := json.NewDecoder(r).Decode(&tf); err != nil {
+		return nil, err
+	}
+	s := &Scope{}
+	add := func(list []targetEntry, exclude bool) {
+		for _, e := range list {
+			typ := strings.ToLower(e.AssetType)
+			if typ == "" {
+				typ = strings.ToLower(e.Type)
+			}
+			if !assetTypes[typ] {
+				continue
+			}
+			addIdentifiers(s, e.identifier(), exclude)
+		}
+	}
+	add(tf.Targets.InScope, false)
+	add(tf.Targets.OutOfScope, true)
+	return s, nil
+}
+
+// addIdentifiers menambahkan satu identifier platform. Identifier bisa berisi
+// beberapa host dipisah koma / spasi; entri yang tidak bisa diparse dilewati
+// (mis. "Other assets") dan dicatat di s.Warnings.
+func addIdentifiers(s *Scope, raw string, exclude bool) {
+	for _, id := range strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n'
+	}) {
+		if err := s.Add(id, exclude); err != nil {
+			s.Warnings = append(s.Warnings, fmt.Sprintf("%v, dilewati", err))
+		}
+	}
+}
@@ -0,0 +1,313 @@
+// Package scope memuat definisi scope program bug bounty dan memutuskan
+// apakah sebuah host / URL boleh discan.
+//
+// Format teks (satu aturan per baris, # untuk komentar):
+//
+//	*.example.com            wildcard: semua subdomain example.com (bukan apex)
+//	example.com              host persis
+//	api-*.example.com        wildcard di tengah label
+//	10.0.0.0/24              CIDR (hanya untuk target berupa IP)
+//	example.com/app          prefix path: hanya URL di bawah /app
+//	!admin.example.com       pengecualian (juga "-admin.example.com")
+//
+// Baris judul seperti "In scope", "Out of scope", "[in-scope]" atau
+// "## Out of Scope" (gaya salinan halaman HackerOne/Bugcrowd) mengubah
+// bagian berikutnya menjadi aturan include / exclude. File .csv (export scope
+// HackerOne) dan .json (bounty-targets: targets.in_scope / out_of_scope)
+// juga bisa dimuat langsung.
+//
+// Token yang bukan host (tanpa titik dan bukan IP/CIDR, mis. judul kolom
+// "Asset") atau wildcard tanpa domain ("*") dilewati dan dicatat di
+// Scope.Warnings, supaya salinan tabel tidak membuka scope ke semua host.
+package scope
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Rule adalah satu aturan scope.
+type Rule struct {
+	Raw     string // teks asli (untuk log / alasan)
+	Exclude bool
+
+	host   *regexp.Regexp // nil untuk aturan CIDR
+	exact  string         // host persis (tanpa wildcard)
+	cidr   *net.IPNet
+	prefix string // prefix path, "" = semua path
+}
+
+// Scope adalah kumpulan aturan include dan exclude.
+type Scope struct {
+	Source   string // path file asal
+	Includes []Rule
+	Excludes []Rule
+	Warnings []string // aturan yang dilewati saat memuat
+}
+
+// Empty reports whether the scope has no rules at all.
+func (s *Scope) Empty() bool {
+	return s == nil || len(s.Includes)+len(s.Excludes) == 0
+}
+
+// Load reads a scope file; format ditentukan dari ekstensi (.csv / .json)
+// atau isi (teks).
+func Load(path string) (*Scope, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var s *Scope
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		s, err = parseHackerOneCSV(f)
+	case ".json":
+		s, err = parseTargetsJSON(f)
+	default:
+		s, err = Parse(f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	s.Source = path
+	return s, nil
+}
+
+var sectionRe = regexp.MustCompile(`(?i)^[#\[\s]*(in|out[\s_-]*of)[\s_-]*scope[\s:\]]*$`)
+
+// Parse reads the text scope format.
+func Parse(r io.Reader) (*Scope, error) {
+	s := &Scope{}
+	exclude := false
+	sc := bufio.NewScanner(r)
+	n := 0
+	for sc.Scan() {
+		n++
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		if m := sectionRe.FindStringSubmatch(line); m != nil {
+			exclude = !strings.EqualFold(m[1], "in")
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		// Bullet list Markdown ("- *.example.com").
+		for _, b := range []string{"- ", "* ", "• "} {
+			if strings.HasPrefix(line, b) {
+				line = strings.TrimSpace(line[len(b):])
+				break
+			}
+		}
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		// Salinan tabel sering berisi kolom tambahan; ambil kolom pertama.
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		line = fields[0]
+		if err := s.Add(line, exclude); err != nil {
+			s.Warnings = append(s.Warnings, fmt.Sprintf("baris %d: %v, dilewati", n, err))
+		}
+	}
+	return s, sc.Err()
+}
+
+// Add parses one rule. Prefix "!" atau "-" menjadikannya pengecualian.
+func (s *Scope) Add(raw string, exclude bool) error {
+	raw = strings.TrimSpace(raw)
+	if strings.HasPrefix(raw, "!") || strings.HasPrefix(raw, "-") {
+		exclude = true
+		raw = strings.TrimSpace(raw[1:])
+	}
+	r, err := parseRule(raw)
+	if err != nil {
+		return err
+	}
+	r.Exclude = exclude
+	if exclude {
+		s.Excludes = append(s.Excludes, r)
+	} else {
+		s.Includes = append(s.Includes, r)
+	}
+	return nil
+}
+
+func parseRule(raw string) (Rule, error) {
+	r := Rule{Raw: raw}
+	if raw == "" {
+		return r, fmt.Errorf("aturan kosong")
+	}
+	if _, ipnet, err := net.ParseCIDR(raw); err == nil {
+		r.cidr = ipnet
+		return r, nil
+	}
+
+	v := raw
+	if i := strings.Index(v, "://"); i >= 0 {
+		v = v[i+3:]
+	}
+	host, path := v, ""
+	if i := strings.Index(v, "/"); i >= 0 {
+		host, path = v[:i], v[i:]
+	}
+	host = strings.ToLower(stripPort(host))
+	if host == "" {
+		return r, fmt.Errorf("host kosong: %q", raw)
+	}
+	if path != "" && path != "/" && path != "/*" {
+		r.prefix = strings.TrimSuffix(strings.TrimSuffix(path, "*"), "/")
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		bits := 32
+		if ip.To4() == nil {
+			bits = 128
+		}
+		r.cidr = &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+		return r, nil
+	}
+	if strings.Trim(host, "*.") == "" {
+		return r, fmt.Errorf("wildcard tanpa domain: %q", raw)
+	}
+	if !strings.Contains(host, ".") {
+		return r, fmt.Errorf("bukan host / IP / CIDR: %q", raw)
+	}
+	if !strings.Contains(host, "*") {
+		r.exact = host
+		return r, nil
+	}
+	pat := regexp.QuoteMeta(host)
+	pat = strings.ReplaceAll(pat, `\*`, `[^/]+`)
+	re, err := regexp.Compile("^" + pat + "$")
+	if err != nil {
+		return r, fmt.Errorf("wildcard tidak valid %q: %w", raw, err)
+	}
+	r.host = re
+	return r, nil
+}
+
+// matchHost reports whether the rule covers host (path diabaikan).
+func (r Rule) matchHost(host string) bool {
+	if r.cidr != nil {
+		ip := net.ParseIP(host)
+		return ip != nil && r.cidr.Contains(ip)
+	}
+	if r.exact != "" {
+		return host == r.exact
+	}
+	return r.host != nil && r.host.MatchString(host)
+}
+
+// matchPath: path "" (entri tanpa path, mis. subdomain) hanya cocok dengan
+// aturan tanpa prefix.
+func (r Rule) matchPath(path string, hasPath bool) bool {
+	if r.prefix == "" {
+		return true
+	}
+	if !hasPath {
+		return false
+	}
+	return path == r.prefix || strings.HasPrefix(path, r.prefix+"/") ||
+		strings.HasPrefix(path, r.prefix+"?") || strings.HasPrefix(path, r.prefix+"#")
+}
+
+// Check decides whether entry (subdomain, host, atau URL) is in scope.
+// Untuk entri tanpa path (subs / host hasil httpx), aturan include berprefix
+// path tetap meloloskan host-nya; aturan exclude berprefix hanya berlaku
+// untuk URL. Return alasan bila entri dibuang.
+func (s *Scope) Check(entry string) (ok bool, reason string) {
+	if s.Empty() {
+		return true, ""
+	}
+	host, path, hasPath, err := split(entry)
+	if err != nil {
+		return false, err.Error()
+	}
+
+	for _, r := range s.Excludes {
+		if r.matchHost(host) && r.matchPath(path, hasPath) {
+			return false, "exclude " + r.Raw
+		}
+	}
+	if len(s.Includes) == 0 {
+		return true, ""
+	}
+	for _, r := range s.Includes {
+		if !r.matchHost(host) {
+			continue
+		}
+		if !hasPath || r.matchPath(path, true) {
+			return true, ""
+		}
+	}
+	return false, "tidak cocok dengan aturan in-scope"
+}
+
+// split memecah entri menjadi host (lowercase, tanpa port) dan path.
+func split(entry string) (host, path string, hasPath bool, err error) {
+	entry = strings.TrimSpace(entry)
+	if !strings.Contains(entry, "://") {
+		host = entry
+		if i := strings.IndexAny(entry, "/?#"); i >= 0 {
+			host, path, hasPath = entry[:i], entry[i:], true
+		}
+		host = strings.ToLower(stripPort(host))
+		if host == "" {
+			return "", "", false, fmt.Errorf("host kosong")
+		}
+		return host, path, hasPath, nil
+	}
+	u, err := url.Parse(entry)
+	if err != nil || u.Hostname() == "" {
+		return "", "", false, fmt.Errorf("URL tidak valid")
+	}
+	path = u.EscapedPath()
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	hasPath = path != "" && path != "/"
+	return strings.ToLower(u.Hostname()), path, hasPath, nil
+}
+
+func stripPort(host string) string {
+	if strings.HasPrefix(host, "[") {
+		if i := strings.Index(host, "]"); i > 0 {
+			return host[1:i]
+		}
+	}
+	if strings.Count(host, ":") == 1 {
+		host = host[:strings.Index(host, ":")]
+	}
+	return host
+}
+
+// Write menulis scope dalam format teks (dipakai `bugx scope import`).
+func (s *Scope) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	if s.Source != "" {
+		fmt.Fprintf(bw, "# diimpor dari %s\n", filepath.Base(s.Source))
+	}
+	bw.WriteString("[in-scope]\n")
+	for _, r := range s.Includes {
+		fmt.Fprintln(bw, r.Raw)
+	}
+	bw.WriteString("\n[out-of-scope]\n")
+	for _, r := range s.Excludes {
+		fmt.Fprintln(bw, r.Raw)
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,215 @@
+package scope
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func mustParse(t *testing.T, text string) *Scope {
+	t.Helper()
+	s, err := Parse(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestCheck(t *testing.T) {
+	s := mustParse(t, `
+# program example
+*.example.com
+example.org
+api-*.example.net
+10.0.0.0/24
+shop.example.io/app
+
+## Out of Scope
+- admin.example.com
+!*.internal.example.com
+-example.org/private
+`)
+	tests := []struct {
+		entry string
+		want  bool
+	}{
+		// wildcard vs apex
+		{"dev.example.com", true},
+		{"a.b.example.com", true},
+		{"https://dev.example.com:8443/login", true},
+		{"example.com", false},
+		{"notexample.com", false},
+		{"example.org", true},
+		{"www.example.org", false},
+		{"API-v1.example.net", true},
+		{"api.example.net", false},
+		// CIDR
+		{"10.0.0.7", true},
+		{"http://10.0.0.200/x", true},
+		{"10.0.1.1", false},
+		// prefix path: host tetap lolos, URL hanya di bawah /app
+		{"shop.example.io", true},
+		{"https://shop.example.io/app/cart?id=1", true},
+		{"https://shop.example.io/app", true},
+		{"https://shop.example.io/application", false},
+		{"https://shop.example.io/other", false},
+		// exclude mengalahkan include
+		{"admin.example.com", false},
+		{"https://admin.example.com/", false},
+		{"db.internal.example.com", false},
+		{"https://example.org/private/x", false},
+		{"https://example.org/public", true},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got, reason := s.Check(tt.entry); got != tt.want {
+			t.Errorf("Check(%q) = %v (%s), want %v", tt.entry, got, reason, tt.want)
+		}
+	}
+}
+
+func TestCheckEmptyAndExcludeOnly(t *testing.T) {
+	var empty *Scope
+	if ok, _ := empty.Check("anything.com"); !ok {
+		t.Error("scope nil harus meloloskan semua")
+	}
+	s := mustParse(t, "[out-of-scope]\nadmin.example.com\n")
+	if ok, _ := s.Check("www.example.com"); !ok {
+		t.Error("scope tanpa include harus meloloskan entri yang tidak di-exclude")
+	}
+	if ok, _ := s.Check("admin.example.com"); ok {
+		t.Error("admin.example.com harus dibuang")
+	}
+}
+
+func TestParseSkipsNonHosts(t *testing.T) {
+	s := mustParse(t, `Asset  Type  Bounty
+Identifier
+*
+* 
+- 
+-
+*.*
+in-scope.example.com  Wildcard  Yes
+- *.example.com
+::1
+`)
+	var got []string
+	for _, r := range s.Includes {
+		got = append(got, r.Raw)
+	}
+	want := []string{"in-scope.example.com", "*.example.com", "::1"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Includes = %q, want %q", got, want)
+	}
+	// "- *.example.com" adalah bullet Markdown, bukan pengecualian.
+	if len(s.Excludes) != 0 {
+		t.Errorf("Excludes = %v, want kosong", s.Excludes)
+	}
+	if ok, _ := s.Check("random.other.com"); ok {
+		t.Error("wildcard \"*\" tidak boleh meloloskan semua host")
+	}
+	if len(s.Warnings) != 7 {
+		t.Errorf("Warnings = %d, want 7:\n%s", len(s.Warnings), strings.Join(s.Warnings, "\n"))
+	}
+}
+
+func TestLoadHackerOneCSV(t *testing.T) {
+	path := writeTemp(t, "scopes.csv", `identifier,asset_type,instruction,eligible_for_bounty,eligible_for_submission,max_severity
+*.example.com,WILDCARD,,true,true,critical
+"api.example.com, www.example.com",URL,,true,true,high
+com.example.android,GOOGLE_PLAY_APP_ID,,true,true,high
+staging.example.com,URL,,false,false,none
+Other assets,OTHER,,false,true,none
+10.1.0.0/16,CIDR,,true,true,medium
+`)
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Source != path {
+		t.Errorf("Source = %q", s.Source)
+	}
+	if got := raws(s.Includes); got != "*.example.com,api.example.com,www.example.com,10.1.0.0/16" {
+		t.Errorf("Includes = %s", got)
+	}
+	if got := raws(s.Excludes); got != "staging.example.com" {
+		t.Errorf("Excludes = %s", got)
+	}
+	if ok, _ := s.Check("staging.example.com"); ok {
+		t.Error("eligible_for_submission=false harus di luar scope")
+	}
+	if ok, _ := s.Check("10.1.2.3"); !ok {
+		t.Error("10.1.2.3 harus in-scope (CIDR)")
+	}
+
+	if _, err := Load(writeTemp(t, "bad.csv", "name,type\nx,y\n")); err == nil {
+		t.Error("CSV tanpa kolom identifier harus error")
+	}
+}
+
+func TestLoadTargetsJSON(t *testing.T) {
+	path := writeTemp(t, "targets.json", `{
+  "targets": {
+    "in_scope": [
+      {"asset_identifier": "*.example.com", "asset_type": "WILDCARD"},
+      {"target": "https://app.example.org/api", "type": "api"},
+      {"endpoint": "example.net", "type": "domain"},
+      {"asset_identifier": "com.example.ios", "asset_type": "APPLE_STORE_APP_ID"}
+    ],
+    "out_of_scope": [
+      {"target": "blog.example.com", "type": "website"}
+    ]
+  }
+}`)
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := raws(s.Includes); got != "*.example.com,https://app.example.org/api,example.net" {
+		t.Errorf("Includes = %s", got)
+	}
+	if got := raws(s.Excludes); got != "blog.example.com" {
+		t.Errorf("Excludes = %s", got)
+	}
+	for entry, want := range map[string]bool{
+		"blog.example.com":                 false,
+		"https://app.example.org/api/v1":   true,
+		"https://app.example.org/internal": false,
+		"example.net":                      true,
+	} {
+		if ok, _ := s.Check(entry); ok != want {
+			t.Errorf("Check(%q) = %v, want %v", entry, ok, want)
+		}
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	s := mustParse(t, "*.example.com\n!admin.example.com\n")
+	var b strings.Builder
+	if err := s.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	back := mustParse(t, b.String())
+	if raws(back.Includes) != "*.example.com" || raws(back.Excludes) != "admin.example.com" {
+		t.Errorf("round trip:\n%s", b.String())
+	}
+}
+
+func raws(rules []Rule) string {
+	var out []string
+	for _, r := range rules {
+		out = append(out, r.Raw)
+	}
+	return strings.Join(out, ",")
+}
+
+func writeTemp(t *testing.T, name, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
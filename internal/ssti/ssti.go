@@ -0,0 +1,205 @@
+// Package ssti mendeteksi server-side template injection: probe aritmetika
+// (polyglot lalu per engine) di parameter query, dianggap rentan bila hasil
+// evaluasinya muncul di respons, dan engine-nya dikenali dari probe yang
+// cocok.
+package ssti
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+	"github.com/D0Lv-1N/BUGx/internal/probe"
+)
+
+// product adalah hasil 1337*1337; angka ini jarang muncul di halaman biasa
+// dan payload mentahnya tidak memuatnya (refleksi biasa tidak terdeteksi).
+const product = "1787569"
+
+// Engine template yang dikenali.
+const (
+	EngineJinja2     = "jinja2"
+	EngineTwig       = "twig"
+	EngineFreemarker = "freemarker"
+	EngineVelocity   = "velocity"
+	EngineERB        = "erb"
+	EngineGo         = "go"
+	EngineEL         = "el"
+	EngineUnknown    = "unknown"
+)
+
+// engineNames: nama tampilan engine.
+var engineNames = map[string]string{
+	EngineJinja2:     "Jinja2",
+	EngineTwig:       "Twig",
+	EngineFreemarker: "Freemarker",
+	EngineVelocity:   "Velocity",
+	EngineERB:        "ERB / EJS",
+	EngineGo:         "Go template",
+	EngineEL:         "Expression Language (${})",
+	EngineUnknown:    "engine tidak dikenal",
+}
+
+// Probe adalah satu payload dan string yang muncul bila dievaluasi.
+type Probe struct {
+	Engine  string // "" = polyglot (deteksi tanpa fingerprint)
+	Payload string
+	Expect  []string
+}
+
+// Detect dikirim ke setiap parameter. Polyglot mencakup {{ }}, ${ }, <%= %>
+// dan #{ }; Velocity dan Go template tidak mengevaluasi polyglot (Go gagal
+// parse seluruh template), jadi diuji terpisah.
+var Detect = []Probe{
+	{"", "{{1337*1337}}${1337*1337}<%=1337*1337%>#{1337*1337}", []string{product, "1,787,569", "1.787.569"}},
+	{EngineVelocity, "#set($b=1337*1337)${b}", []string{product}},
+	{EngineGo, `{{printf "%s%d" "bugx" 1337}}`, []string{"bugx1337"}},
+}
+
+// Fingerprint dikirim bila polyglot dievaluasi, untuk mengenali engine.
+// Jinja2 mengulang string ('1337'*2 -> 13371337), Twig mengalikan angka.
+var Fingerprint = []Probe{
+	{EngineJinja2, "{{'1337'*2}}", []string{"13371337"}},
+	{EngineTwig, "{{'1337'*2}}", []string{"2674"}},
+	{EngineFreemarker, "<#assign b=1337*1337>${b?c}", []string{product}},
+	{EngineERB, "<%= 1337*1337 %>", []string{product}},
+	{EngineEL, "${1337*1337}", []string{product}},
+}
+
+// Issue adalah parameter yang mengevaluasi template.
+type Issue struct {
+	URL      string // request bukti (probe engine, atau polyglot)
+	Param    string
+	Engine   string
+	Payload  string
+	Matched  string
+	Engines  []string // semua engine yang cocok
+	Severity string
+	Title    string
+}
+
+// severity: engine dengan jalur RCE yang dikenal critical; Go template (hanya
+// method yang diekspos) dan engine tidak dikenal high.
+func severity(engine string) string {
+	switch engine {
+	case EngineGo, EngineUnknown:
+		return findings.SevHigh
+	}
+	return findings.SevCritical
+}
+
+// Checker mengirim probe ke parameter URL.
+type Checker struct {
+	Client *probe.Client
+}
+
+// Check menguji setiap parameter query target. Respons asli (baseline)
+// dibandingkan supaya angka yang memang ada di halaman tidak dihitung.
+func (c *Checker) Check(ctx context.Context, target string) ([]Issue, error) {
+	u, err := url.Parse(target)
+	if err != nil || u.Host == "" || u.RawQuery == "" {
+		return nil, err
+	}
+	baseline, err := c.fetch(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	var out []Issue
+	pairs := strings.Split(u.RawQuery, "&")
+	prefix := u.Scheme + "://" + u.Host + u.EscapedPath() + "?"
+	for i, pair := range pairs {
+		name, _, _ := strings.Cut(pair, "=")
+		if name == "" {
+			continue
+		}
+		inject := func(payload string) string {
+			q := append([]string(nil), pairs...)
+			q[i] = name + "=" + url.QueryEscape(payload)
+			return prefix + strings.Join(q, "&")
+		}
+		is, ok, err := c.checkParam(ctx, inject, baseline)
+		if err != nil {
+			return out, err
+		}
+		if ok {
+			is.Param = name
+			out = append(out, is)
+		}
+	}
+	return out, nil
+}
+
+// checkParam menjalankan Detect lalu (bila polyglot dievaluasi) Fingerprint.
+func (c *Checker) checkParam(ctx context.Context, inject func(string) string, baseline string) (Issue, bool, error) {
+	var hit *Issue
+	for _, p := range Detect {
+		is, ok, err := c.try(ctx, p, inject, baseline)
+		if err != nil {
+			return Issue{}, false, err
+		}
+		if ok {
+			hit = &is
+			break
+		}
+	}
+	if hit == nil {
+		return Issue{}, false, nil
+	}
+	if hit.Engine == "" {
+		hit.Engine = EngineUnknown
+		for _, p := range Fingerprint {
+			is, ok, err := c.try(ctx, p, inject, baseline)
+			if err != nil {
+				return Issue{}, false, err
+			}
+			if !ok {
+				continue
+			}
+			if hit.Engine == EngineUnknown {
+				engines := hit.Engines
+				*hit = is
+				hit.Engines = engines
+			}
+			hit.Engines = append(hit.Engines, p.Engine)
+		}
+	}
+	if len(hit.Engines) == 0 {
+		hit.Engines = []string{hit.Engine}
+	}
+	hit.Severity = severity(hit.Engine)
+	hit.Title = "SSTI (" + EngineName(hit.Engine) + ")"
+	return *hit, true, nil
+}
+
+// try mengirim satu probe; cocok bila salah satu Expect muncul di respons
+// tetapi tidak di baseline.
+func (c *Checker) try(ctx context.Context, p Probe, inject func(string) string, baseline string) (Issue, bool, error) {
+	target := inject(p.Payload)
+	body, err := c.fetch(ctx, target)
+	if err != nil {
+		return Issue{}, false, err
+	}
+	for _, want := range p.Expect {
+		if strings.Contains(body, want) && !strings.Contains(baseline, want) {
+			return Issue{URL: target, Engine: p.Engine, Payload: p.Payload, Matched: want}, true, nil
+		}
+	}
+	return Issue{}, false, nil
+}
+
+func (c *Checker) fetch(ctx context.Context, target string) (string, error) {
+	resp, err := c.Client.Get(ctx, target, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(resp.Body), nil
+}
+
+// EngineName returns nama tampilan engine.
+func EngineName(engine string) string {
+	if n, ok := engineNames[engine]; ok {
+		return n
+	}
+	return engine
+}
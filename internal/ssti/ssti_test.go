@@ -0,0 +1,85 @@
+package ssti
+
+import (
+	"context"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/D0Lv-1N/BUGx/internal/findings"
+	"github.com/D0Lv-1N/BUGx/internal/probe"
+)
+
+// jinja meniru Jinja2 untuk ekspresi yang dipakai probe.
+var jinja = strings.NewReplacer("{{1337*1337}}", product, "{{'1337'*2}}", "13371337")
+
+func testServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/jinja", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Hello " + jinja.Replace(r.URL.Query().Get("name"))))
+	})
+	mux.HandleFunc("/gotmpl", func(w http.ResponseWriter, r *http.Request) {
+		t, err := template.New("").Parse(r.URL.Query().Get("name"))
+		if err != nil {
+			http.Error(w, "template error", http.StatusInternalServerError)
+			return
+		}
+		t.Execute(w, nil)
+	})
+	mux.HandleFunc("/safe", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Hello " + html.EscapeString(r.URL.Query().Get("name"))))
+	})
+	// Halaman yang memang memuat 1787569 (nomor order) dan memantulkan
+	// parameter tanpa evaluasi: bukan SSTI.
+	mux.HandleFunc("/order", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Order #" + product + " untuk " + r.URL.Query().Get("name")))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestCheck(t *testing.T) {
+	srv := testServer()
+	defer srv.Close()
+	chk := &Checker{Client: &probe.Client{HTTP: srv.Client()}}
+
+	tests := []struct {
+		path   string
+		engine string // "" = tidak rentan
+		sev    string
+	}{
+		{"/jinja?name=bob&x=1", EngineJinja2, findings.SevCritical},
+		{"/gotmpl?name=bob", EngineGo, findings.SevHigh},
+		{"/safe?name=bob", "", ""},
+		{"/order?name=bob", "", ""},
+	}
+	for _, tt := range tests {
+		issues, err := chk.Check(context.Background(), srv.URL+tt.path)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+		if tt.engine == "" {
+			if len(issues) != 0 {
+				t.Errorf("%s: false positive %+v", tt.path, issues)
+			}
+			continue
+		}
+		if len(issues) != 1 {
+			t.Fatalf("%s: issues = %+v, want 1 (hanya parameter name)", tt.path, issues)
+		}
+		is := issues[0]
+		if is.Param != "name" || is.Engine != tt.engine || is.Severity != tt.sev {
+			t.Errorf("%s: %+v, want engine %s severity %s", tt.path, is, tt.engine, tt.sev)
+		}
+	}
+}
+
+func TestCheckWithoutQuery(t *testing.T) {
+	chk := &Checker{Client: &probe.Client{}}
+	issues, err := chk.Check(context.Background(), "http://127.0.0.1:1/no-query")
+	if err != nil || issues != nil {
+		t.Errorf("tanpa query: %v, %v (tidak boleh mengirim request)", issues, err)
+	}
+}
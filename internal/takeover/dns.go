@@ -0,0 +1,276 @@
+package takeover
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// DNS record types dan rcode yang dipakai.
+const (
+	typeA     = 1
+	typeCNAME = 5
+
+	rcodeNXDomain = 3
+)
+
+// maxCNAMEHops membatasi rantai CNAME yang diikuti.
+const maxCNAMEHops = 8
+
+// errMalformed / errMismatch / errTruncated: respons DNS tidak bisa dipakai
+// (errTruncated: bit TC, answer tidak lengkap; diulang lewat TCP).
+var (
+	errMalformed = errors.New("respons DNS tidak valid")
+	errMismatch  = errors.New("ID respons DNS tidak cocok")
+	errTruncated = errors.New("respons DNS terpotong (TC)")
+)
+
+// Resolver adalah client DNS minimal (UDP) yang melihat record CNAME dan
+// rcode NXDOMAIN secara langsung; net.Resolver menyembunyikan CNAME bila
+// target akhirnya NXDOMAIN, padahal itu kasus dangling yang dicari.
+type Resolver struct {
+	Server  string        // host:port
+	Timeout time.Duration // per percobaan (default 3s)
+	Retries int           // default 2
+}
+
+// SystemServer returns the first nameserver in /etc/resolv.conf as host:port.
+func SystemServer() (string, error) {
+	f, err := os.Open("/etc/resolv.conf")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			return net.JoinHostPort(fields[1], "53"), nil
+		}
+	}
+	return "", errors.New("tidak ada nameserver di /etc/resolv.conf")
+}
+
+// Chain mengikuti rantai CNAME name (tanpa titik akhir). Return daftar
+// target berurutan (kosong bila name tidak punya CNAME) dan apakah nama
+// terakhir NXDOMAIN. name sendiri yang NXDOMAIN juga dilaporkan lewat nx.
+func (r *Resolver) Chain(ctx context.Context, name string) (chain []string, nx bool, err error) {
+	cur := strings.TrimSuffix(strings.ToLower(name), ".")
+	for i := 0; i < maxCNAMEHops; i++ {
+		rcode, targets, err := r.query(ctx, cur, typeCNAME)
+		if err != nil {
+			return chain, false, err
+		}
+		if rcode == rcodeNXDomain {
+			return chain, true, nil
+		}
+		if len(targets) == 0 {
+			break
+		}
+		cur = targets[0]
+		chain = append(chain, cur)
+	}
+	if len(chain) == 0 {
+		return nil, false, nil
+	}
+	rcode, _, err := r.query(ctx, cur, typeA)
+	if err != nil {
+		return chain, false, err
+	}
+	return chain, rcode == rcodeNXDomain, nil
+}
+
+// query mengirim satu pertanyaan dan mengembalikan rcode serta target
+// record CNAME di bagian answer (hanya untuk qtype CNAME).
+func (r *Resolver) query(ctx context.Context, name string, qtype uint16) (int, []string, error) {
+	msg, id, err := buildQuery(name, qtype)
+	if err != nil {
+		return 0, nil, err
+	}
+	timeout, retries := r.Timeout, r.Retries
+	if timeout <= 0 {
+		timeout = 3 * time.Second
+	}
+	if retries <= 0 {
+		retries = 2
+	}
+	var lastErr error
+	for attempt := 0; attempt < retries; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return 0, nil, err
+		}
+		rcode, targets, err := r.exchange(ctx, "udp", msg, id, timeout)
+		if errors.Is(err, errTruncated) {
+			rcode, targets, err = r.exchange(ctx, "tcp", msg, id, timeout)
+		}
+		if err == nil {
+			return rcode, targets, nil
+		}
+		lastErr = err
+	}
+	return 0, nil, fmt.Errorf("dns %s: %w", name, lastErr)
+}
+
+// exchange mengirim msg lewat network "udp" atau "tcp" (pesan diawali
+// panjang 2 byte, RFC 1035 4.2.2).
+func (r *Resolver) exchange(ctx context.Context, network string, msg []byte, id uint16, timeout time.Duration) (int, []string, error) {
+	d := net.Dialer{Timeout: timeout}
+	conn, err := d.DialContext(ctx, network, r.Server)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer conn.Close()
+	deadline := time.Now().Add(timeout)
+	if dl, ok := ctx.Deadline(); ok && dl.Before(deadline) {
+		deadline = dl
+	}
+	_ = conn.SetDeadline(deadline)
+	if network == "tcp" {
+		return exchangeStream(conn, msg, id)
+	}
+	if _, err := conn.Write(msg); err != nil {
+		return 0, nil, err
+	}
+	buf := make([]byte, 4096)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return 0, nil, err
+	}
+	return parseResponse(buf[:n], id)
+}
+
+func exchangeStream(conn net.Conn, msg []byte, id uint16) (int, []string, error) {
+	out := make([]byte, 2, 2+len(msg))
+	binary.BigEndian.PutUint16(out, uint16(len(msg)))
+	if _, err := conn.Write(append(out, msg...)); err != nil {
+		return 0, nil, err
+	}
+	var size [2]byte
+	if _, err := io.ReadFull(conn, size[:]); err != nil {
+		return 0, nil, err
+	}
+	buf := make([]byte, binary.BigEndian.Uint16(size[:]))
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		return 0, nil, err
+	}
+	return parseResponse(buf, id)
+}
+
+// buildQuery -> header (RD=1) + satu question IN.
+func buildQuery(name string, qtype uint16) ([]byte, uint16, error) {
+	var idb [2]byte
+	_, _ = rand.Read(idb[:])
+	id := binary.BigEndian.Uint16(idb[:])
+
+	msg := make([]byte, 12, 12+len(name)+6)
+	binary.BigEndian.PutUint16(msg[0:], id)
+	binary.BigEndian.PutUint16(msg[2:], 0x0100)
+	binary.BigEndian.PutUint16(msg[4:], 1)
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		if label == "" || len(label) > 63 {
+			return nil, 0, fmt.Errorf("nama DNS tidak valid: %q", name)
+		}
+		msg = append(msg, byte(len(label)))
+		msg = append(msg, label...)
+	}
+	msg = append(msg, 0, byte(qtype>>8), byte(qtype), 0, 1)
+	return msg, id, nil
+}
+
+// parseResponse returns rcode dan target CNAME dari bagian answer. Respons
+// dengan bit TC ditolak: daftar answer-nya belum tentu lengkap.
+func parseResponse(msg []byte, id uint16) (int, []string, error) {
+	if len(msg) < 12 {
+		return 0, nil, errMalformed
+	}
+	if binary.BigEndian.Uint16(msg[0:]) != id {
+		return 0, nil, errMismatch
+	}
+	if msg[2]&0x02 != 0 {
+		return 0, nil, errTruncated
+	}
+	rcode := int(msg[3] & 0x0f)
+	qd := int(binary.BigEndian.Uint16(msg[4:]))
+	an := int(binary.BigEndian.Uint16(msg[6:]))
+
+	off := 12
+	for i := 0; i < qd; i++ {
+		_, next, err := readName(msg, off)
+		if err != nil {
+			return 0, nil, err
+		}
+		off = next + 4
+	}
+	var targets []string
+	for i := 0; i < an; i++ {
+		_, next, err := readName(msg, off)
+		if err != nil {
+			return 0, nil, err
+		}
+		off = next
+		if off+10 > len(msg) {
+			return 0, nil, errMalformed
+		}
+		rtype := binary.BigEndian.Uint16(msg[off:])
+		rdlen := int(binary.BigEndian.Uint16(msg[off+8:]))
+		off += 10
+		if off+rdlen > len(msg) {
+			return 0, nil, errMalformed
+		}
+		if rtype == typeCNAME {
+			target, _, err := readName(msg, off)
+			if err != nil {
+				return 0, nil, err
+			}
+			targets = append(targets, target)
+		}
+		off += rdlen
+	}
+	return rcode, targets, nil
+}
+
+// readName membaca nama (dengan kompresi pointer) mulai di off. Return nama
+// lowercase tanpa titik akhir dan offset setelah nama di posisi asli.
+func readName(msg []byte, off int) (string, int, error) {
+	var labels []string
+	next := -1
+	for jumps := 0; ; {
+		if off >= len(msg) {
+			return "", 0, errMalformed
+		}
+		l := int(msg[off])
+		switch {
+		case l == 0:
+			if next < 0 {
+				next = off + 1
+			}
+			return strings.ToLower(strings.Join(labels, ".")), next, nil
+		case l&0xc0 == 0xc0:
+			if off+1 >= len(msg) || jumps > 16 {
+				return "", 0, errMalformed
+			}
+			if next < 0 {
+				next = off + 2
+			}
+			off = int(binary.BigEndian.Uint16(msg[off:]) & 0x3fff)
+			jumps++
+		case l&0xc0 != 0:
+			// 0x40 / 0x80: tipe label extended (RFC 6891) tidak didukung.
+			return "", 0, errMalformed
+		default:
+			if off+1+l > len(msg) {
+				return "", 0, errMalformed
+			}
+			labels = append(labels, string(msg[off+1:off+1+l]))
+			off += 1 + l
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package takeover
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// encodeName -> label wire format tanpa kompresi.
+func encodeName(name string) []byte {
+	var b []byte
+	for _, l := range strings.Split(name, ".") {
+		b = append(b, byte(len(l)))
+		b = append(b, l...)
+	}
+	return append(b, 0)
+}
+
+// header -> 12 byte header DNS.
+func header(id, flags uint16, qd, an int) []byte {
+	h := make([]byte, 12)
+	binary.BigEndian.PutUint16(h[0:], id)
+	binary.BigEndian.PutUint16(h[2:], flags)
+	binary.BigEndian.PutUint16(h[4:], uint16(qd))
+	binary.BigEndian.PutUint16(h[6:], uint16(an))
+	return h
+}
+
+// rr -> resource record dengan owner (sudah di-encode) dan rdata.
+func rr(owner []byte, rtype uint16, rdata []byte) []byte {
+	b := append([]byte(nil), owner...)
+	fixed := make([]byte, 10)
+	binary.BigEndian.PutUint16(fixed[0:], rtype)
+	binary.BigEndian.PutUint16(fixed[2:], 1)
+	binary.BigEndian.PutUint32(fixed[4:], 300)
+	binary.BigEndian.PutUint16(fixed[8:], uint16(len(rdata)))
+	return append(append(b, fixed...), rdata...)
+}
+
+func question(name string, qtype uint16) []byte {
+	return append(encodeName(name), byte(qtype>>8), byte(qtype), 0, 1)
+}
+
+// ptr -> pointer kompresi ke offset.
+func ptr(off int) []byte { return []byte{0xc0 | byte(off>>8), byte(off)} }
+
+func concat(parts ...[]byte) []byte {
+	var b []byte
+	for _, p := range parts {
+		b = append(b, p...)
+	}
+	return b
+}
+
+func TestReadName(t *testing.T) {
+	// "example.com" di offset 0, "www" + pointer ke 0 di offset 13.
+	base := concat(encodeName("example.com"), []byte{3, 'w', 'w', 'w'}, ptr(0))
+	tests := []struct {
+		name     string
+		msg      []byte
+		off      int
+		want     string
+		wantNext int
+		wantErr  bool
+	}{
+		{"plain", encodeName("Sub.Example.COM"), 0, "sub.example.com", 17, false},
+		{"root", []byte{0}, 0, "", 1, false},
+		{"pointer", base, 13, "www.example.com", 19, false},
+		{"pointer only", concat(encodeName("a.b"), ptr(0)), 5, "a.b", 7, false},
+		{"pointer loop", ptr(0), 0, "", 0, true},
+		{"pointer pair loop", concat(ptr(2), ptr(0)), 0, "", 0, true},
+		{"pointer past end", ptr(40), 0, "", 0, true},
+		{"pointer cut", []byte{0xc0}, 0, "", 0, true},
+		{"label past end", []byte{5, 'a', 'b'}, 0, "", 0, true},
+		{"missing terminator", []byte{1, 'a'}, 0, "", 0, true},
+		{"offset past end", []byte{0}, 3, "", 0, true},
+		{"extended label", []byte{0x41, 0}, 0, "", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, next, err := readName(tt.msg, tt.off)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (got != tt.want || next != tt.wantNext) {
+				t.Errorf("readName = (%q, %d), want (%q, %d)", got, next, tt.want, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	const id = 0x1234
+	q := question("www.example.com", typeCNAME)
+	// Owner answer = pointer ke question (offset 12), target CNAME memakai
+	// pointer ke "example.com" di dalam question (offset 16).
+	cname := rr(ptr(12), typeCNAME, concat([]byte{4, 'g', 'o', 'n', 'e'}, ptr(16)))
+	a := rr(ptr(12), typeA, []byte{127, 0, 0, 1})
+
+	tests := []struct {
+		name      string
+		msg       []byte
+		wantRcode int
+		want      []string
+		wantErr   error
+	}{
+		{"cname compressed", concat(header(id, 0x8180, 1, 1), q, cname), 0, []string{"gone.example.com"}, nil},
+		{"cname + a", concat(header(id, 0x8180, 1, 2), q, cname, a), 0, []string{"gone.example.com"}, nil},
+		{"no answer", concat(header(id, 0x8180, 1, 0), q), 0, nil, nil},
+		{"nxdomain", concat(header(id, 0x8183, 1, 0), q), rcodeNXDomain, nil, nil},
+		{"truncated bit", concat(header(id, 0x8380, 1, 1), q, cname), 0, nil, errTruncated},
+		{"id mismatch", concat(header(id+1, 0x8180, 1, 1), q, cname), 0, nil, errMismatch},
+		{"short header", []byte{0x12, 0x34, 0x81}, 0, nil, errMalformed},
+		{"answer count past end", concat(header(id, 0x8180, 1, 2), q, cname), 0, nil, errMalformed},
+		{"rdlength past end", concat(header(id, 0x8180, 1, 1), q, cname[:len(cname)-3]), 0, nil, errMalformed},
+		{"short fixed part", concat(header(id, 0x8180, 1, 1), q, ptr(12), []byte{0, 5}), 0, nil, errMalformed},
+		{"cname pointer loop", concat(header(id, 0x8180, 1, 1), q, rr(ptr(12), typeCNAME, ptr(len(q)+12+12))), 0, nil, errMalformed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rcode, targets, err := parseResponse(tt.msg, id)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rcode != tt.wantRcode || !reflect.DeepEqual(targets, tt.want) {
+				t.Errorf("parseResponse = (%d, %v), want (%d, %v)", rcode, targets, tt.wantRcode, tt.want)
+			}
+		})
+	}
+}
+
+// zone adalah data stand-in: CNAME per nama, nama yang ada (A), nama yang
+// di UDP selalu dijawab dengan TC.
+type zone struct {
+	cname     map[string]string
+	exists    map[string]bool
+	truncated map[string]bool
+}
+
+// answer menyusun respons untuk query; tc = kirim versi terpotong.
+func (z zone) answer(query []byte, tc bool) []byte {
+	id := binary.BigEndian.Uint16(query)
+	name, next, err := readName(query, 12)
+	if err != nil {
+		return nil
+	}
+	qtype := binary.BigEndian.Uint16(query[next:])
+	q := query[12 : next+4]
+
+	flags := uint16(0x8180)
+	var ans []byte
+	an := 0
+	switch target, ok := z.cname[name]; {
+	case tc && z.truncated[name]:
+		flags |= 0x0200
+	case ok && qtype == typeCNAME:
+		ans, an = rr(ptr(12), typeCNAME, encodeName(target)), 1
+	case ok || z.exists[name]:
+		if qtype == typeA {
+			ans, an = rr(ptr(12), typeA, []byte{127, 0, 0, 1}), 1
+		}
+	default:
+		flags |= rcodeNXDomain
+	}
+	return concat(header(id, flags, 1, an), q, ans)
+}
+
+// serve menjalankan stand-in UDP + TCP di port yang sama.
+func (z zone) serve(t *testing.T) string {
+	t.Helper()
+	tl, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pc, err := net.ListenPacket("udp", tl.Addr().String())
+	if err != nil {
+		tl.Close()
+		t.Skipf("port UDP stand-in tidak tersedia: %v", err)
+	}
+	t.Cleanup(func() { pc.Close(); tl.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := pc.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if resp := z.answer(buf[:n], true); resp != nil {
+				pc.WriteTo(resp, addr)
+			}
+		}
+	}()
+	go func() {
+		for {
+			conn, err := tl.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				var size [2]byte
+				if _, err := io.ReadFull(conn, size[:]); err != nil {
+					return
+				}
+				query := make([]byte, binary.BigEndian.Uint16(size[:]))
+				if _, err := io.ReadFull(conn, query); err != nil {
+					return
+				}
+				resp := z.answer(query, false)
+				out := make([]byte, 2)
+				binary.BigEndian.PutUint16(out, uint16(len(resp)))
+				conn.Write(append(out, resp...))
+			}()
+		}
+	}()
+	return tl.Addr().String()
+}
+
+func TestResolverChain(t *testing.T) {
+	z := zone{
+		cname: map[string]string{
+			"dangling.example.test": "gone-bucket.s3.test",
+			"live.example.test":     "app.cdn.test",
+			"hop1.example.test":     "hop2.example.test",
+			"hop2.example.test":     "gone.azure.test",
+			"big.example.test":      "gone.pages.test",
+		},
+		exists:    map[string]bool{"app.cdn.test": true, "plain.example.test": true},
+		truncated: map[string]bool{"big.example.test": true},
+	}
+	r := &Resolver{Server: z.serve(t), Timeout: 2 * time.Second, Retries: 1}
+
+	tests := []struct {
+		name      string
+		wantChain []string
+		wantNX    bool
+	}{
+		{"dangling.example.test", []string{"gone-bucket.s3.test"}, true},
+		{"live.example.test", []string{"app.cdn.test"}, false},
+		{"hop1.example.test", []string{"hop2.example.test", "gone.azure.test"}, true},
+		{"plain.example.test", nil, false},
+		{"missing.example.test", nil, true},
+		// UDP menjawab TC; answer lengkap hanya lewat TCP.
+		{"big.example.test", []string{"gone.pages.test"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain, nx, err := r.Chain(context.Background(), tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(chain, tt.wantChain) || nx != tt.wantNX {
+				t.Errorf("Chain = (%v, %v), want (%v, %v)", chain, nx, tt.wantChain, tt.wantNX)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package takeover
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Fingerprint mengenali satu layanan pihak ketiga yang bisa diambil alih bila
+// subdomain masih menunjuk (CNAME) ke resource yang sudah dihapus.
+type Fingerprint struct {
+	Service string   `json:"service"`
+	CNAME   []string `json:"cname"`          // suffix target CNAME, mis. "s3.amazonaws.com"
+	Body    []string `json:"body,omitempty"` // potongan halaman error "resource tidak ada"
+	// NXDomain: target CNAME yang NXDOMAIN berarti nama resource bisa
+	// didaftarkan ulang (Azure, Elastic Beanstalk, ...).
+	NXDomain bool `json:"nxdomain,omitempty"`
+}
+
+// Fingerprints adalah tabel bawaan, diringkas dari daftar layanan yang
+// terkonfirmasi rentan (can-i-take-over-xyz). Tambahan / override lokal
+// dibaca dengan LoadFingerprints.
+var Fingerprints = []Fingerprint{
+	{Service: "AWS S3", CNAME: []string{"s3.amazonaws.com", "s3-website", ".s3."}, Body: []string{"NoSuchBucket", "The specified bucket does not exist"}},
+	{Service: "AWS Elastic Beanstalk", CNAME: []string{"elasticbeanstalk.com"}, NXDomain: true},
+	{Service: "GitHub Pages", CNAME: []string{"github.io"}, Body: []string{"There isn't a GitHub Pages site here."}},
+	{Service: "Heroku", CNAME: []string{"herokuapp.com", "herokudns.com", "herokussl.com"}, Body: []string{"No such app", "herokucdn.com/error-pages/no-such-app.html"}},
+	{Service: "Microsoft Azure", CNAME: []string{
+		"azurewebsites.net", "cloudapp.net", "cloudapp.azure.com", "trafficmanager.net",
+		"blob.core.windows.net", "azureedge.net", "azure-api.net", "azurehdinsight.net",
+		"azurecontainer.io", "database.windows.net", "azurefd.net", "redis.cache.windows.net",
+	}, Body: []string{"404 Web Site not found"}, NXDomain: true},
+	{Service: "Fastly", CNAME: []string{"fastly.net"}, Body: []string{"Fastly error: unknown domain"}},
+	{Service: "Shopify", CNAME: []string{"myshopify.com", "shops.myshopify.com"}, Body: []string{"Sorry, this shop is currently unavailable."}},
+	{Service: "Tumblr", CNAME: []string{"domains.tumblr.com"}, Body: []string{"Whatever you were looking for doesn't currently exist at this address"}},
+	{Service: "Zendesk", CNAME: []string{"zendesk.com"}, Body: []string{"Help Center Closed"}},
+	{Service: "Pantheon", CNAME: []string{"pantheonsite.io"}, Body: []string{"The gods are wise, but do not know of the site which you seek."}},
+	{Service: "Surge.sh", CNAME: []string{"surge.sh"}, Body: []string{"project not found"}},
+	{Service: "Bitbucket", CNAME: []string{"bitbucket.io"}, Body: []string{"Repository not found"}},
+	{Service: "Ghost", CNAME: []string{"ghost.io"}, Body: []string{"The thing you were looking for is no longer here, or never was"}},
+	{Service: "Help Scout", CNAME: []string{"helpscoutdocs.com"}, Body: []string{"No settings were found for this company:"}},
+	{Service: "ReadMe.io", CNAME: []string{"readme.io"}, Body: []string{"Project doesnt exist... yet!"}},
+	{Service: "Strikingly", CNAME: []string{"s.strikinglydns.com"}, Body: []string{"PAGE NOT FOUND."}},
+	{Service: "Unbounce", CNAME: []string{"unbouncepages.com"}, Body: []string{"The requested URL was not found on this server."}},
+	{Service: "Webflow", CNAME: []string{"proxy.webflow.com", "proxy-ssl.webflow.com"}, Body: []string{"The page you are looking for doesn't exist or has been moved."}},
+	{Service: "WordPress.com", CNAME: []string{"wordpress.com"}, Body: []string{"Do you want to register"}},
+	{Service: "Netlify", CNAME: []string{"netlify.app", "netlify.com"}, Body: []string{"Not Found - Request ID:"}},
+	{Service: "Agile CRM", CNAME: []string{"agilecrm.com"}, Body: []string{"Sorry, this page is no longer available."}},
+	{Service: "Fly.io", CNAME: []string{"fly.dev"}, NXDomain: true},
+}
+
+// LoadFingerprints membaca tabel tambahan (JSON array Fingerprint). Entri
+// dengan Service yang sama mengganti entri bawaan.
+func LoadFingerprints(path string) ([]Fingerprint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var list []Fingerprint
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for i, fp := range list {
+		if fp.Service == "" || len(fp.CNAME) == 0 {
+			return nil, fmt.Errorf("%s: entri %d butuh service dan cname", path, i+1)
+		}
+	}
+	return list, nil
+}
+
+// Merge returns base with extra appended; extra menggantikan entri base
+// dengan Service yang sama (case-insensitive).
+func Merge(base, extra []Fingerprint) []Fingerprint {
+	out := make([]Fingerprint, 0, len(base)+len(extra))
+	for _, b := range base {
+		replaced := false
+		for _, e := range extra {
+			if strings.EqualFold(b.Service, e.Service) {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			out = append(out, b)
+		}
+	}
+	return append(out, extra...)
+}
+
+// Match returns the fingerprint whose CNAME pattern occurs in target. Pola
+// dicocokkan sebagai substring supaya varian regional ikut kena
+// (bucket.s3.eu-west-1.amazonaws.com, *.s3-website-...).
+func Match(fps []Fingerprint, target string) (Fingerprint, bool) {
+	target = strings.ToLower(strings.TrimSuffix(target, "."))
+	for _, fp := range fps {
+		for _, c := range fp.CNAME {
+			if c = strings.ToLower(c); c != "" && strings.Contains(target, c) {
+				return fp, true
+			}
+		}
+	}
+	return Fingerprint{}, false
+}
@@ -0,0 +1,104 @@
+// Package takeover mendeteksi kandidat subdomain takeover: subdomain yang
+// CNAME-nya menunjuk ke layanan pihak ketiga (S3, GitHub Pages, Heroku,
+// Azure, ...) yang resource-nya sudah tidak ada.
+package takeover
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/D0Lv-1N/BUGx/internal/probe"
+)
+
+// MaxBody adalah batas body halaman yang dibaca untuk fingerprint (isi
+// ke probe.Client.MaxBody).
+const MaxBody = 256 << 10
+
+// Candidate adalah satu subdomain yang kemungkinan bisa diambil alih.
+type Candidate struct {
+	Host    string
+	Chain   []string // rantai CNAME, target terakhir di ujung
+	Service string   // "" = dangling CNAME tanpa fingerprint layanan
+	Reason  string
+	URL     string // halaman yang cocok fingerprint body (bila ada)
+	Match   string // potongan body yang cocok
+	Confirm bool   // NXDOMAIN pada layanan yang bisa didaftarkan ulang / body cocok
+}
+
+// CNAME returns the final CNAME target.
+func (c Candidate) CNAME() string {
+	if len(c.Chain) == 0 {
+		return ""
+	}
+	return c.Chain[len(c.Chain)-1]
+}
+
+// Checker memeriksa satu host. Client dipakai untuk mengambil halaman
+// error layanan (sebaiknya tanpa follow redirect); error BeforeRequest-nya
+// menghentikan pengecekan host.
+type Checker struct {
+	DNS          *Resolver
+	Client       *probe.Client
+	Fingerprints []Fingerprint
+}
+
+// Check returns a candidate for host, atau nil bila tidak ada indikasi.
+// - CNAME ke layanan dikenal + target NXDOMAIN (layanan NXDomain) -> kandidat.
+// - CNAME ke layanan dikenal + halaman error layanan -> kandidat.
+// - CNAME apa pun yang targetnya NXDOMAIN -> dangling CNAME (perlu dicek manual).
+func (c *Checker) Check(ctx context.Context, host string) (*Candidate, error) {
+	chain, nx, err := c.DNS.Chain(ctx, host)
+	if err != nil || len(chain) == 0 {
+		return nil, err
+	}
+	cand := &Candidate{Host: host, Chain: chain}
+
+	var fp Fingerprint
+	matched := false
+	for _, name := range chain {
+		if fp, matched = Match(c.Fingerprints, name); matched {
+			cand.Service = fp.Service
+			break
+		}
+	}
+
+	if nx {
+		cand.Reason = fmt.Sprintf("target CNAME %s NXDOMAIN", cand.CNAME())
+		cand.Confirm = matched && fp.NXDomain
+		if matched && !fp.NXDomain {
+			cand.Reason += " (" + fp.Service + " biasanya butuh cek body)"
+		}
+		return cand, nil
+	}
+	if !matched || len(fp.Body) == 0 {
+		return nil, nil
+	}
+
+	for _, scheme := range []string{"https://", "http://"} {
+		url := scheme + host + "/"
+		body, err := c.fetch(ctx, url)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			continue
+		}
+		for _, sig := range fp.Body {
+			if strings.Contains(body, sig) {
+				cand.URL, cand.Match, cand.Confirm = url, sig, true
+				cand.Reason = fmt.Sprintf("halaman error %s: %q", fp.Service, sig)
+				return cand, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (c *Checker) fetch(ctx context.Context, url string) (string, error) {
+	resp, err := c.Client.Get(ctx, url, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(resp.Body), nil
+}
@@ -6,8 +6,22 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/D0Lv-1N/BUGx/internal/diff"
+	"github.com/D0Lv-1N/BUGx/internal/findings"
 )
 
+// topFindingsLimit is how many findings the summary box lists.
+const topFindingsLimit = 10
+
+// MenuItem is one selectable scan mode in the main menu.
+type MenuItem struct {
+	Number int
+	Title  string
+}
+
 // MenuSelection represents the user's chosen scan modes.
 type MenuSelection struct {
 	Modes []int
@@ -27,19 +41,23 @@ func PrintHeader() {
 	fmt.Println("==================================================")
 }
 
+// PrintRunLine mencetak header satu baris untuk mode CLI (cron / CI), sebagai
+// ganti banner menu interaktif.
+func PrintRunLine(action string) {
+	fmt.Printf("[BUGx] %s (%s)\n", action, time.Now().Format("2006-01-02 15:04:05"))
+}
+
 // PrintMainMenu renders the mode selection menu.
-func PrintMainMenu() {
+// Items berisi mode bawaan dan mode custom (~/BUGx/modes/) sesuai urutan menu.
+func PrintMainMenu(items []MenuItem) {
 	PrintHeader()
 	fmt.Println("Pilih mode scan (bisa lebih dari satu, pisahkan dengan koma):")
 	fmt.Println()
-	fmt.Println(" 1. XSS")
-	fmt.Println(" 2. SQLi")
-	fmt.Println(" 3. LFI / RFI")
-	fmt.Println(" 4. SSRF")
-	fmt.Println(" 5. Open Redirect")
-	fmt.Println(" 6. Sensitive Files / Backup")
-	fmt.Println(" 7. CMS / Panel")
-	fmt.Println(" 8. RCE / High Impact")
+	for _, it := range items {
+		fmt.Printf("%2d. %s\n", it.Number, it.Title)
+	}
+	// RUN ALL di luar urutan nomor (9 < 10..54), jadi dipisah di akhir.
+	fmt.Println("--------------------------------------------------")
 	fmt.Println(" 9. RUN ALL")
 	fmt.Println(" 0. Keluar")
 	fmt.Println()
@@ -77,7 +95,7 @@ func ReadModes() MenuSelection {
 			// Jika ada 0 di kombinasi, interpretasi sebagai keluar
 			return MenuSelection{Modes: nil, Exit: true}
 		}
-		if n < 0 || n > 9 {
+		if n < 0 {
 			continue
 		}
 		if _, ok := seen[n]; !ok {
@@ -98,12 +116,14 @@ func PrintSetupTarget() {
 	fmt.Println("Masukan target dan kecepatan scan.")
 	fmt.Println("Contoh target: https://example.com")
 	fmt.Println("Kecepatan mempengaruhi flags tools eksternal (threads/conc).")
+	fmt.Println("Batas request (req/detik) dipetakan ke -rl nuclei/httpx, --delay dalfox.")
 	fmt.Println("--------------------------------------------------")
 }
 
-// ReadTarget prompts and reads the target URL.
+// ReadTarget prompts and reads the target URL. Beberapa target boleh
+// dipisah koma, atau "@file" untuk daftar target (satu per baris).
 func ReadTarget() string {
-	fmt.Print("Masukan target url (http(s)://example.com): ")
+	fmt.Print("Masukan target url (http(s)://example.com, a.com,b.com atau @targets.txt): ")
 	raw := strings.TrimSpace(readLine())
 	return raw
 }
@@ -122,10 +142,51 @@ func ReadSpeed(defaultSpeed int) int {
 	return n
 }
 
+// ReadRateLimit prompts for the global requests-per-second cap (0 = tanpa batas).
+func ReadRateLimit() int {
+	fmt.Print("Batas request per detik (default 0 = tanpa batas): ")
+	raw := strings.TrimSpace(readLine())
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// ReadAuth prompts for an auth header ("Nama: nilai") or @file (kosong =
+// header default per domain bila ada).
+func ReadAuth() string {
+	fmt.Print("Header auth (\"Cookie: sid=...\" / \"Authorization: Bearer ...\" / @file, kosong = default): ")
+	return strings.TrimSpace(readLine())
+}
+
+// ReadProxy prompts for an upstream proxy URL (kosong = tanpa proxy).
+func ReadProxy() string {
+	fmt.Print("Proxy upstream (mis. http://127.0.0.1:8080, kosong = tanpa proxy): ")
+	return strings.TrimSpace(readLine())
+}
+
+// ReadWorkers prompts for the parallel worker budget (with default).
+func ReadWorkers(defaultWorkers int) int {
+	fmt.Printf("Worker paralel (tool/step & target bersamaan, default %d = berurutan): ", defaultWorkers)
+	raw := strings.TrimSpace(readLine())
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return defaultWorkers
+	}
+	return n
+}
+
 // PrintRunHeader shows the processing screen header.
 func PrintRunHeader(target string, speed int, modes []int) {
 	ClearScreen()
 	PrintHeader()
+	PrintRunDetails(target, speed, modes)
+}
+
+// PrintRunDetails prints the run parameters without clearing the screen
+// (dipakai juga oleh mode CLI agar log cron/CI tidak berisi escape code).
+func PrintRunDetails(target string, speed int, modes []int) {
 	fmt.Println("Proses scanning dimulai")
 	fmt.Println("--------------------------------------------------")
 	fmt.Printf("Target  : %s\n", target)
@@ -136,8 +197,35 @@ func PrintRunHeader(target string, speed int, modes []int) {
 	fmt.Println()
 }
 
-// PrintSummary renders a simple summary box after scans.
-func PrintSummary(target string, modes []int, toolsUsed []string) {
+// Timing adalah waktu run untuk ringkasan: Wall = wall clock, Steps = total
+// durasi semua step (sama dengan wall clock bila berjalan berurutan).
+type Timing struct {
+	Wall  time.Duration
+	Steps time.Duration
+}
+
+// String -> "4m10s (total step 9m2s, hemat 4m52s / 54%)".
+func (t Timing) String() string {
+	wall := t.Wall.Round(time.Second)
+	saved := (t.Steps - t.Wall).Round(time.Second)
+	if saved <= 0 || t.Steps <= 0 {
+		return wall.String()
+	}
+	return fmt.Sprintf("%s (total step %s, hemat %s / %d%%)",
+		wall, t.Steps.Round(time.Second), saved, int(100*saved/t.Steps))
+}
+
+// PrintSummary renders a simple summary box after scans and waits for ENTER.
+func PrintSummary(target string, modes []int, toolsUsed []string, found []findings.Finding, d *diff.Run, t Timing) {
+	RenderSummary(target, modes, toolsUsed, found, d, t)
+	fmt.Print("Tekan ENTER untuk kembali ke menu utama...")
+	_ = readLine()
+}
+
+// RenderSummary renders the summary box without waiting for input.
+// d (opsional) menambahkan bagian "baru sejak scan terakhir"; t kosong =
+// baris waktu tidak ditampilkan.
+func RenderSummary(target string, modes []int, toolsUsed []string, found []findings.Finding, d *diff.Run, t Timing) {
 	fmt.Println()
 	fmt.Println("==================================================")
 	fmt.Println("                    RINGKASAN                     ")
@@ -149,11 +237,153 @@ func PrintSummary(target string, modes []int, toolsUsed []string) {
 	} else {
 		fmt.Println("Tools Used  : (tidak terdeteksi / tidak dicatat)")
 	}
+	if t.Wall > 0 {
+		fmt.Printf("Waktu       : %s\n", t)
+	}
+	printFindings(found)
+	printDiff(d, found)
 	fmt.Println("==================================================")
+}
+
+// TargetSummary adalah satu baris ringkasan gabungan scan multi-target.
+type TargetSummary struct {
+	Target   string
+	RunID    string
+	Status   string
+	Tools    []string
+	Findings []findings.Finding
+	Diff     *diff.Run
+	Timing   Timing
+}
+
+// PrintBatchSummary renders the combined multi-target summary and waits for ENTER.
+func PrintBatchSummary(modes []int, rows []TargetSummary, total Timing) {
+	RenderBatchSummary(modes, rows, total)
 	fmt.Print("Tekan ENTER untuk kembali ke menu utama...")
 	_ = readLine()
 }
 
+// RenderBatchSummary renders one line per target plus the most severe
+// findings across all targets. total adalah waktu seluruh batch.
+func RenderBatchSummary(modes []int, rows []TargetSummary, total Timing) {
+	fmt.Println()
+	fmt.Println("==================================================")
+	fmt.Println("               RINGKASAN MULTI-TARGET             ")
+	fmt.Println("==================================================")
+	fmt.Printf("Target      : %d\n", len(rows))
+	fmt.Printf("Mode(s)     : %v\n", modes)
+	if total.Wall > 0 {
+		fmt.Printf("Waktu       : %s\n", total)
+	}
+	fmt.Println("--------------------------------------------------")
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TARGET\tRUN ID\tSTATUS\tTOOLS\tC/H/M/L/I\tBARU\tWAKTU")
+	var all []findings.Finding
+	for _, r := range rows {
+		c := findings.CountBySeverity(r.Findings)
+		fresh := "-"
+		if r.Diff != nil && r.Diff.PrevID != "" {
+			fresh = fmt.Sprintf("+%d", r.Diff.NewFindings)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d/%d/%d/%d/%d\t%s\t%s\n",
+			r.Target, r.RunID, r.Status, len(r.Tools),
+			c[findings.SevCritical], c[findings.SevHigh], c[findings.SevMedium], c[findings.SevLow], c[findings.SevInfo],
+			fresh, r.Timing.Wall.Round(time.Second))
+		all = append(all, r.Findings...)
+	}
+	tw.Flush()
+	fmt.Println("--------------------------------------------------")
+	findings.SortBySeverity(all)
+	printFindings(all)
+	fmt.Println("==================================================")
+}
+
+// diffSampleLimit is how many new assets per corpus the summary lists.
+const diffSampleLimit = 5
+
+// printDiff prints what is new compared to the previous run of the domain.
+func printDiff(d *diff.Run, found []findings.Finding) {
+	if d == nil {
+		return
+	}
+	fmt.Println("--------------------------------------------------")
+	if d.PrevID == "" {
+		fmt.Println("Baru        : (belum ada run sebelumnya untuk domain ini)")
+		return
+	}
+	fmt.Printf("Baru sejak  : run %s\n", d.PrevID)
+	printAssetDiff("Subdomain", d.Subs)
+	printAssetDiff("Host live", d.Hosts)
+	printAssetDiff("URL gau", d.URLs)
+
+	var fresh []findings.Finding
+	for _, f := range found {
+		if f.New {
+			fresh = append(fresh, f)
+		}
+	}
+	fmt.Printf("  %-10s: +%d\n", "Findings", len(fresh))
+	for _, f := range findings.Top(fresh, topFindingsLimit) {
+		name := f.Name
+		if name == "" {
+			name = f.ID
+		}
+		fmt.Printf("    [%-8s] %-9s %s -> %s\n", strings.ToUpper(f.Severity), f.Mode, name, f.URL)
+	}
+	if len(fresh) > topFindingsLimit {
+		fmt.Printf("    ... %d temuan baru lain\n", len(fresh)-topFindingsLimit)
+	}
+}
+
+func printAssetDiff(label string, a diff.Assets) {
+	if a.Total == 0 && a.New == 0 {
+		return
+	}
+	if !a.Baseline {
+		fmt.Printf("  %-10s: %d (run sebelumnya tidak punya data ini)\n", label, a.Total)
+		return
+	}
+	fmt.Printf("  %-10s: +%d dari %d\n", label, a.New, a.Total)
+	for i, v := range a.Sample {
+		if i == diffSampleLimit {
+			fmt.Printf("    ... lihat %s\n", a.File)
+			break
+		}
+		fmt.Printf("    + %s\n", v)
+	}
+}
+
+// printFindings prints severity counts and the most severe findings.
+func printFindings(found []findings.Finding) {
+	if len(found) == 0 {
+		fmt.Println("Findings    : 0")
+		return
+	}
+
+	counts := findings.CountBySeverity(found)
+	var parts []string
+	for _, sev := range findings.Severities {
+		if n := counts[sev]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", sev, n))
+		}
+	}
+	fmt.Printf("Findings    : %d (%s)\n", len(found), strings.Join(parts, ", "))
+
+	top := findings.Top(found, topFindingsLimit)
+	fmt.Printf("%-12s:\n", fmt.Sprintf("Top %d", len(top)))
+	for _, f := range top {
+		name := f.Name
+		if name == "" {
+			name = f.ID
+		}
+		fmt.Printf("  [%-8s] %-9s %s -> %s\n", strings.ToUpper(f.Severity), f.Mode, name, f.URL)
+	}
+	if len(found) > len(top) {
+		fmt.Printf("  ... %d temuan lain (lihat folder results)\n", len(found)-len(top))
+	}
+}
+
 // readLine reads a single line from stdin (trimmed).
 func readLine() string {
 	reader := bufio.NewReader(os.Stdin)